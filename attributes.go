@@ -0,0 +1,154 @@
+package ansicsi
+
+import "io"
+
+const (
+	DeviceFeatureColumns132          = 1  // 132 columns
+	DeviceFeaturePrinter             = 2  // Printer port
+	DeviceFeatureReGIS               = 3  // ReGIS graphics
+	DeviceFeatureSixel               = 4  // Sixel graphics
+	DeviceFeatureSelectiveErase      = 6  // Selective erase
+	DeviceFeatureUserDefinedKeys     = 8  // User-defined keys
+	DeviceFeatureNationalCharsets    = 9  // National replacement character sets
+	DeviceFeatureTechnicalCharacters = 15 // Technical character set
+	DeviceFeatureLocatorPort         = 16 // Locator port
+	DeviceFeatureStateInterrogation  = 17 // Terminal state interrogation
+	DeviceFeatureUserWindows         = 18 // User windows
+	DeviceFeatureHorizontalScrolling = 21 // Horizontal scrolling
+	DeviceFeatureANSIColor           = 22 // ANSI color
+	DeviceFeatureRectangularEditing  = 28 // Rectangular editing
+	DeviceFeatureANSITextLocator     = 29 // ANSI text locator
+)
+
+// PrimaryDeviceAttributes represents a DEVICE ATTRIBUTES (DA) control function that requests the primary device
+// attributes of a terminal, e.g. ESC[c. The terminal replies with a PrimaryDeviceAttributesReport.
+type PrimaryDeviceAttributes struct{}
+
+func (d *PrimaryDeviceAttributes) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{0}, nil, 0x63)
+}
+
+func (d *PrimaryDeviceAttributes) decodeParameters(params []int) bool {
+	return decodeAttributesRequest(params)
+}
+
+// SecondaryDeviceAttributes represents a request for the secondary device attributes of a terminal, e.g. ESC[>c. The
+// terminal replies with a SecondaryDeviceAttributesReport.
+type SecondaryDeviceAttributes struct{}
+
+func (d *SecondaryDeviceAttributes) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '>', []int{0}, nil, 0x63)
+}
+
+func (d *SecondaryDeviceAttributes) decodeParameters(params []int) bool {
+	return decodeAttributesRequest(params)
+}
+
+// TertiaryDeviceAttributes represents a request for the tertiary device attributes of a terminal, e.g. ESC[=c. The
+// terminal replies with a device control string that carries its unit ID, which is not a control sequence and cannot
+// be decoded by this package.
+type TertiaryDeviceAttributes struct{}
+
+func (d *TertiaryDeviceAttributes) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '=', []int{0}, nil, 0x63)
+}
+
+func (d *TertiaryDeviceAttributes) decodeParameters(params []int) bool {
+	return decodeAttributesRequest(params)
+}
+
+// PrimaryDeviceAttributesReport represents a terminal's reply to a PrimaryDeviceAttributes request, e.g.
+// ESC[?62;1;22c.
+type PrimaryDeviceAttributesReport struct {
+	// ServiceClass identifies the terminal's conformance level. VT100-series terminals report 1 or 6; later terminals
+	// report 60 plus their conformance level, e.g. 62 for a VT200-series terminal.
+	ServiceClass int
+	// Features lists the extensions that the terminal supports. Most are DeviceFeature* constants.
+	Features []int
+}
+
+// ConformanceLevel returns the terminal's conformance level, e.g. 1 for a VT100-series terminal or 2 for a VT200-series
+// terminal.
+func (d *PrimaryDeviceAttributesReport) ConformanceLevel() int {
+	if d.ServiceClass > 60 {
+		return d.ServiceClass - 60
+	}
+	return 1
+}
+
+// HasFeature returns true if the terminal reported support for the given feature.
+func (d *PrimaryDeviceAttributesReport) HasFeature(feature int) bool {
+	for _, f := range d.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *PrimaryDeviceAttributesReport) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '?', append([]int{d.ServiceClass}, d.Features...), nil, 0x63)
+}
+
+func (d *PrimaryDeviceAttributesReport) decodeParameters(params []int) bool {
+	if len(params) == 0 {
+		return false
+	}
+	for _, p := range params {
+		if p < 0 {
+			return false
+		}
+	}
+	d.ServiceClass, d.Features = params[0], append([]int{}, params[1:]...)
+	return true
+}
+
+// SecondaryDeviceAttributesReport represents a terminal's reply to a SecondaryDeviceAttributes request, e.g.
+// ESC[>41;390;0c.
+type SecondaryDeviceAttributesReport struct {
+	// TerminalID identifies the type of terminal, e.g. 1 for a VT220 or 41 for a VT420.
+	TerminalID int
+	// FirmwareVersion is the terminal's firmware version. Terminal emulators often report their own version here.
+	FirmwareVersion int
+	// ROMCartridge is the terminal's ROM cartridge registration number. This is usually 0.
+	ROMCartridge int
+}
+
+func (d *SecondaryDeviceAttributesReport) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '>', []int{d.TerminalID, d.FirmwareVersion, d.ROMCartridge}, nil, 0x63)
+}
+
+func (d *SecondaryDeviceAttributesReport) decodeParameters(params []int) bool {
+	return decodePs(params, &d.TerminalID, &d.FirmwareVersion, &d.ROMCartridge)
+}
+
+// secondaryDeviceAttributesSequence decodes a control sequence that is either a SecondaryDeviceAttributes request or
+// a SecondaryDeviceAttributesReport. Requests and reports share a private marker and final byte; requests have at
+// most one parameter.
+type secondaryDeviceAttributesSequence struct {
+	cmd Command
+}
+
+func (d *secondaryDeviceAttributesSequence) Encode(w io.Writer) (int, error) {
+	return d.cmd.Encode(w)
+}
+
+func (d *secondaryDeviceAttributesSequence) decodeParameters(params []int) bool {
+	if len(params) <= 1 {
+		d.cmd = &SecondaryDeviceAttributes{}
+	} else {
+		d.cmd = &SecondaryDeviceAttributesReport{}
+	}
+	return d.cmd.decodeParameters(params)
+}
+
+func (d *secondaryDeviceAttributesSequence) resolveCommand() Command {
+	return d.cmd
+}
+
+// decodeAttributesRequest decodes the parameters of a device attributes request, which takes a single parameter that
+// must be 0 if present.
+func decodeAttributesRequest(params []int) bool {
+	var ps int
+	return decodePs(params, &ps) && ps == 0
+}
@@ -0,0 +1,62 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceAttributes(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[c", cmd: &PrimaryDeviceAttributes{}, encoded: "\x1b[0c"},
+		{command: "\x1b[0c", cmd: &PrimaryDeviceAttributes{}},
+		{command: "\x1b[>c", cmd: &SecondaryDeviceAttributes{}, encoded: "\x1b[>0c"},
+		{command: "\x1b[>0c", cmd: &SecondaryDeviceAttributes{}},
+		{command: "\x1b[=c", cmd: &TertiaryDeviceAttributes{}, encoded: "\x1b[=0c"},
+		{command: "\x1b[?1;2c", cmd: &PrimaryDeviceAttributesReport{ServiceClass: 1, Features: []int{2}}},
+		{command: "\x1b[?6c", cmd: &PrimaryDeviceAttributesReport{ServiceClass: 6, Features: []int{}}},
+		{
+			command: "\x1b[?64;1;2;6;9;15;16;17;18;21;22;28c",
+			cmd: &PrimaryDeviceAttributesReport{
+				ServiceClass: 64,
+				Features:     []int{1, 2, 6, 9, 15, 16, 17, 18, 21, 22, 28},
+			},
+		},
+		{command: "\x1b[>41;390;0c", cmd: &SecondaryDeviceAttributesReport{TerminalID: 41, FirmwareVersion: 390}},
+		{
+			command: "\x1b[>1;10c",
+			cmd:     &SecondaryDeviceAttributesReport{TerminalID: 1, FirmwareVersion: 10},
+			encoded: "\x1b[>1;10;0c",
+		},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestDeviceAttributes_Invalid(t *testing.T) {
+	invalid := []string{"\x1b[1c", "\x1b[0;0c", "\x1b[>1c", "\x1b[=1c", "\x1b[?c", "\x1b[?62;;1c", "\x1b[>1;2;3;4c"}
+	assertUnrecognized(t, invalid...)
+}
+
+func TestPrimaryDeviceAttributesReport(t *testing.T) {
+	cases := []struct {
+		report    PrimaryDeviceAttributesReport
+		level     int
+		sixel     bool
+		ansiColor bool
+	}{
+		{report: PrimaryDeviceAttributesReport{ServiceClass: 1, Features: []int{2}}, level: 1},
+		{report: PrimaryDeviceAttributesReport{ServiceClass: 6}, level: 1},
+		{report: PrimaryDeviceAttributesReport{ServiceClass: 62, Features: []int{22}}, level: 2, ansiColor: true},
+		{
+			report:    PrimaryDeviceAttributesReport{ServiceClass: 65, Features: []int{1, 4, 22}},
+			level:     5,
+			sixel:     true,
+			ansiColor: true,
+		},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.level, c.report.ConformanceLevel())
+		assert.Equal(t, c.sixel, c.report.HasFeature(DeviceFeatureSixel))
+		assert.Equal(t, c.ansiColor, c.report.HasFeature(DeviceFeatureANSIColor))
+	}
+}
@@ -0,0 +1,167 @@
+package ansicsi
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// ColorKind identifies the way in which a Color is selected.
+type ColorKind uint8
+
+const (
+	ColorDefault ColorKind = iota // The default color (implementation-defined)
+	ColorANSI                     // One of the 8 basic or 8 bright ANSI colors
+	ColorIndexed                  // A color from the 256-color palette
+	ColorRGB                      // A 24-bit color
+)
+
+// Color represents a color selected by a Set Graphics Rendition control function. The zero value is the default
+// color.
+//
+// Color implements image/color.Color. ANSI and indexed colors are converted using the xterm palette, and the default
+// color is converted to fully transparent black.
+type Color struct {
+	kind    ColorKind
+	index   uint8
+	r, g, b uint8
+}
+
+var _ color.Color = Color{}
+
+// ANSIColor returns one of the 16 ANSI colors. Indices 0-7 select the basic colors (black, red, green, yellow,
+// blue, magenta, cyan, and white); indices 8-15 select their bright variants. ANSIColor panics if index is out of
+// range.
+func ANSIColor(index int) Color {
+	if index < 0 || index > 15 {
+		panic(fmt.Sprintf("ansicsi: ANSI color index %d out of range", index))
+	}
+	return Color{kind: ColorANSI, index: uint8(index)}
+}
+
+// BrightColor returns the bright variant of the given basic ANSI color (0-7). It is equivalent to
+// ANSIColor(8+index).
+func BrightColor(index int) Color {
+	if index < 0 || index > 7 {
+		panic(fmt.Sprintf("ansicsi: bright color index %d out of range", index))
+	}
+	return ANSIColor(8 + index)
+}
+
+// IndexedColor returns the color at the given index of the 256-color palette.
+func IndexedColor(index uint8) Color {
+	return Color{kind: ColorIndexed, index: index}
+}
+
+// RGBColor returns the given 24-bit color.
+func RGBColor(r, g, b uint8) Color {
+	return Color{kind: ColorRGB, r: r, g: g, b: b}
+}
+
+// Kind returns the kind of the color.
+func (c Color) Kind() ColorKind {
+	return c.kind
+}
+
+// IsDefault returns true if c is the default color.
+func (c Color) IsDefault() bool {
+	return c.kind == ColorDefault
+}
+
+// Index returns the palette index of an ANSI or indexed color. ANSI colors occupy indices 0-15 of the 256-color
+// palette. Index returns 0 for default and 24-bit colors.
+func (c Color) Index() int {
+	return int(c.index)
+}
+
+// RGB returns the RGB value of the color. ANSI and indexed colors are converted using the xterm palette. The default
+// color is reported as black.
+func (c Color) RGB() (r, g, b uint8) {
+	switch c.kind {
+	case ColorANSI, ColorIndexed:
+		return paletteRGB(int(c.index))
+	default:
+		return c.r, c.g, c.b
+	}
+}
+
+// RGBA implements image/color.Color.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	if c.kind == ColorDefault {
+		return 0, 0, 0, 0
+	}
+	r8, g8, b8 := c.RGB()
+	return color.RGBA{R: r8, G: g8, B: b8, A: 0xff}.RGBA()
+}
+
+// String returns a textual representation of the color.
+func (c Color) String() string {
+	switch c.kind {
+	case ColorANSI:
+		return fmt.Sprintf("ansi(%d)", c.index)
+	case ColorIndexed:
+		return fmt.Sprintf("indexed(%d)", c.index)
+	case ColorRGB:
+		return fmt.Sprintf("rgb(%d, %d, %d)", c.r, c.g, c.b)
+	default:
+		return "default"
+	}
+}
+
+// ForegroundColor returns a Set Graphics Rendition control function that selects the given foreground color.
+func ForegroundColor(c Color) *SetGraphicsRendition {
+	return c.sgr(SGRForegroundBlack, SGRForegroundBrightBlack, SGRForegroundColor, SGRForegroundDefault)
+}
+
+// BackgroundColor returns a Set Graphics Rendition control function that selects the given background color.
+func BackgroundColor(c Color) *SetGraphicsRendition {
+	return c.sgr(SGRBackgroundBlack, SGRBackgroundBrightBlack, SGRBackgroundColor, SGRBackgroundDefault)
+}
+
+// UnderlineColor returns a Set Graphics Rendition control function that selects the given underline color. There are
+// no dedicated commands for the ANSI underline colors, so ANSI colors are selected using their palette index.
+func UnderlineColor(c Color) *SetGraphicsRendition {
+	if c.kind == ColorANSI {
+		c.kind = ColorIndexed
+	}
+	return c.sgr(-1, -1, SGRUnderlineColor, SGRDefaultUnderlineColor)
+}
+
+// sgr returns the Set Graphics Rendition control function that selects the color using the given commands.
+func (c Color) sgr(basic, bright, extended, def int) *SetGraphicsRendition {
+	switch {
+	case c.kind == ColorANSI && c.index < 8:
+		return &SetGraphicsRendition{Command: basic + int(c.index), Parameters: []int{}}
+	case c.kind == ColorANSI:
+		return &SetGraphicsRendition{Command: bright + int(c.index) - 8, Parameters: []int{}}
+	case c.kind == ColorIndexed:
+		return &SetGraphicsRendition{Command: extended, Parameters: []int{5, int(c.index)}}
+	case c.kind == ColorRGB:
+		return &SetGraphicsRendition{Command: extended, Parameters: []int{2, int(c.r), int(c.g), int(c.b)}}
+	default:
+		return &SetGraphicsRendition{Command: def, Parameters: []int{}}
+	}
+}
+
+// Color returns the color selected by an SGR command that selects a foreground, background, or underline color. ok is
+// false for all other commands, and for extended colors with out-of-range parameters.
+func (sgr *SetGraphicsRendition) Color() (c Color, ok bool) {
+	switch command := sgr.Command; {
+	case command >= SGRForegroundBlack && command <= SGRForegroundWhite:
+		return ANSIColor(command - SGRForegroundBlack), true
+	case command >= SGRBackgroundBlack && command <= SGRBackgroundWhite:
+		return ANSIColor(command - SGRBackgroundBlack), true
+	case command >= SGRForegroundBrightBlack && command <= SGRForegroundBrightWhite:
+		return BrightColor(command - SGRForegroundBrightBlack), true
+	case command >= SGRBackgroundBrightBlack && command <= SGRBackgroundBrightWhite:
+		return BrightColor(command - SGRBackgroundBrightBlack), true
+	case command == SGRForegroundDefault, command == SGRBackgroundDefault, command == SGRDefaultUnderlineColor:
+		return Color{}, true
+	}
+	if index, ok := sgr.ColorIndex(); ok && index < 256 {
+		return IndexedColor(uint8(index)), true
+	}
+	if r, g, b, ok := sgr.RGB(); ok && r < 256 && g < 256 && b < 256 {
+		return RGBColor(uint8(r), uint8(g), uint8(b)), true
+	}
+	return Color{}, false
+}
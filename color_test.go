@@ -0,0 +1,99 @@
+package ansicsi
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColor_Constructors(t *testing.T) {
+	c := ANSIColor(1)
+	assert.Equal(t, ColorANSI, c.Kind())
+	assert.Equal(t, 1, c.Index())
+	r, g, b := c.RGB()
+	assert.Equal(t, []uint8{0xcd, 0, 0}, []uint8{r, g, b})
+
+	c = BrightColor(4)
+	assert.Equal(t, ColorANSI, c.Kind())
+	assert.Equal(t, 12, c.Index())
+	assert.Equal(t, ANSIColor(12), c)
+
+	c = IndexedColor(196)
+	assert.Equal(t, ColorIndexed, c.Kind())
+	assert.Equal(t, 196, c.Index())
+	r, g, b = c.RGB()
+	assert.Equal(t, []uint8{0xff, 0, 0}, []uint8{r, g, b})
+
+	c = IndexedColor(244)
+	r, g, b = c.RGB()
+	assert.Equal(t, []uint8{0x80, 0x80, 0x80}, []uint8{r, g, b})
+
+	c = RGBColor(1, 2, 3)
+	assert.Equal(t, ColorRGB, c.Kind())
+	assert.Equal(t, 0, c.Index())
+	r, g, b = c.RGB()
+	assert.Equal(t, []uint8{1, 2, 3}, []uint8{r, g, b})
+
+	assert.True(t, Color{}.IsDefault())
+	assert.False(t, c.IsDefault())
+
+	assert.Panics(t, func() { ANSIColor(16) })
+	assert.Panics(t, func() { BrightColor(8) })
+}
+
+func TestColor_ImageColor(t *testing.T) {
+	assert.Equal(t, color.RGBA{R: 0x5f, G: 0x87, B: 0xaf, A: 0xff}, color.RGBAModel.Convert(IndexedColor(67)))
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, color.RGBAModel.Convert(BrightColor(7)))
+	assert.Equal(t, color.RGBA{R: 9, G: 8, B: 7, A: 0xff}, color.RGBAModel.Convert(RGBColor(9, 8, 7)))
+	assert.Equal(t, color.RGBA{}, color.RGBAModel.Convert(Color{}))
+}
+
+func TestColor_String(t *testing.T) {
+	assert.Equal(t, "default", Color{}.String())
+	assert.Equal(t, "ansi(3)", ANSIColor(3).String())
+	assert.Equal(t, "indexed(42)", IndexedColor(42).String())
+	assert.Equal(t, "rgb(1, 2, 3)", RGBColor(1, 2, 3).String())
+}
+
+func TestColor_SGR(t *testing.T) {
+	cases := []struct {
+		sgr      *SetGraphicsRendition
+		color    Color
+		expected string
+	}{
+		{sgr: ForegroundColor(ANSIColor(2)), color: ANSIColor(2), expected: "\x1b[32m"},
+		{sgr: ForegroundColor(BrightColor(2)), color: BrightColor(2), expected: "\x1b[92m"},
+		{sgr: ForegroundColor(IndexedColor(128)), color: IndexedColor(128), expected: "\x1b[38;5;128m"},
+		{sgr: ForegroundColor(RGBColor(32, 64, 128)), color: RGBColor(32, 64, 128), expected: "\x1b[38;2;32;64;128m"},
+		{sgr: ForegroundColor(Color{}), color: Color{}, expected: "\x1b[39m"},
+		{sgr: BackgroundColor(ANSIColor(7)), color: ANSIColor(7), expected: "\x1b[47m"},
+		{sgr: BackgroundColor(BrightColor(0)), color: BrightColor(0), expected: "\x1b[100m"},
+		{sgr: BackgroundColor(IndexedColor(17)), color: IndexedColor(17), expected: "\x1b[48;5;17m"},
+		{sgr: BackgroundColor(Color{}), color: Color{}, expected: "\x1b[49m"},
+		{sgr: UnderlineColor(ANSIColor(1)), color: IndexedColor(1), expected: "\x1b[58;5;1m"},
+		{sgr: UnderlineColor(RGBColor(1, 2, 3)), color: RGBColor(1, 2, 3), expected: "\x1b[58;2;1;2;3m"},
+		{sgr: UnderlineColor(Color{}), color: Color{}, expected: "\x1b[59m"},
+	}
+	for _, c := range cases {
+		var b bytes.Buffer
+		_, err := c.sgr.Encode(&b)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, b.String())
+
+		color, ok := c.sgr.Color()
+		assert.True(t, ok, c.expected)
+		assert.Equal(t, c.color, color, c.expected)
+	}
+
+	cmd, _ := Decode([]byte("\x1b[38:2::10:20:30m"))
+	color, ok := cmd.(*SetGraphicsRendition).Color()
+	assert.True(t, ok)
+	assert.Equal(t, RGBColor(10, 20, 30), color)
+
+	_, ok = (&SetGraphicsRendition{Command: SGRBold}).Color()
+	assert.False(t, ok)
+	_, ok = (&SetGraphicsRendition{Command: SGRForegroundColor, Parameters: []int{5, 256}}).Color()
+	assert.False(t, ok)
+}
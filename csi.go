@@ -19,11 +19,18 @@ type ControlSequence struct {
 	Parameters   []byte
 	Intermediate []byte
 	Final        byte
+
+	// C1 is true if the control sequence uses the 8-bit C1 control sequence introducer (0x9b) rather than ESC [.
+	C1 bool
 }
 
 func (cs *ControlSequence) Encode(w io.Writer) (int, error) {
 	bytes := make([]byte, 0, 2+len(cs.Parameters)+len(cs.Intermediate)+1)
-	bytes = append(bytes, []byte("\x1b[")...)
+	if cs.C1 {
+		bytes = append(bytes, 0x9b)
+	} else {
+		bytes = append(bytes, []byte("\x1b[")...)
+	}
 	bytes = append(bytes, cs.Parameters...)
 	bytes = append(bytes, cs.Intermediate...)
 	bytes = append(bytes, cs.Final)
@@ -34,14 +41,121 @@ func (*ControlSequence) decodeParameters(params []int) bool {
 	return false
 }
 
+// Private returns the private parameter marker ('<', '=', '>', or '?') that begins the control sequence's parameter
+// bytes, or 0 if the parameter bytes do not begin with a private marker.
+func (cs *ControlSequence) Private() byte {
+	return privateMarker(cs.Parameters)
+}
+
+// privateMarker returns the private parameter marker that begins the given parameter bytes, if any. ECMA-48 reserves
+// parameter strings that begin with 0x3c-0x3f for private use.
+func privateMarker(parameters []byte) byte {
+	if len(parameters) > 0 && parameters[0] >= 0x3c && parameters[0] <= 0x3f {
+		return parameters[0]
+	}
+	return 0
+}
+
+// EncodeC1 writes the ANSI control sequence for the given command to w using the 8-bit C1 control sequence
+// introducer (0x9b) in place of ESC [.
+func EncodeC1(w io.Writer, cmd Command) (int, error) {
+	var buf bytes.Buffer
+	if _, err := cmd.Encode(&buf); err != nil {
+		return 0, err
+	}
+	b := buf.Bytes()
+	if len(b) >= 2 && b[0] == 0x1b && b[1] == '[' {
+		b = b[1:]
+		b[0] = 0x9b
+	}
+	return w.Write(b)
+}
+
+// Status describes the result of decoding a control sequence.
+type Status int
+
+const (
+	// StatusNone indicates that the input does not begin with a control sequence introducer.
+	StatusNone Status = iota
+	// StatusOK indicates that a control sequence was decoded.
+	StatusOK
+	// StatusIncomplete indicates that the input is a proper prefix of a control sequence. More data is needed in order
+	// to decode the sequence.
+	StatusIncomplete
+	// StatusInvalid indicates that the input begins with a control sequence introducer, but the control sequence is
+	// malformed. The reported size is the number of bytes to skip in order to discard the malformed sequence.
+	StatusInvalid
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case StatusNone:
+		return "none"
+	case StatusOK:
+		return "ok"
+	case StatusIncomplete:
+		return "incomplete"
+	case StatusInvalid:
+		return "invalid"
+	default:
+		return "Status(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
 // Decode decodes the ANSI control function beginning at the first byte of b and returns the function, its
 // parameters, and its encoded size. If a valid control sequence is found but the control function is not
 // recognized, the raw control sequence is returned as a *ControlSequence value.
+//
+// Decode only recognizes the 7-bit control sequence introducer ESC [. Use DecodeC1 to also recognize the 8-bit
+// control sequence introducer.
 func Decode(b []byte) (Command, int) {
-	if len(b) < 2 || b[0] != 0x1b || b[1] != '[' {
+	cmd, size, status := DecodeStatus(b)
+	if status != StatusOK {
 		return nil, 0
 	}
-	b = b[2:]
+	return cmd, size
+}
+
+// DecodeC1 is like Decode, but also recognizes the 8-bit C1 control sequence introducer (0x9b). Because 0x9b is
+// also a valid UTF-8 continuation byte, DecodeC1 should only be used with input that is known to use 8-bit controls.
+//
+// Only a *ControlSequence records which introducer it was decoded from. Typed commands always encode with ESC [, so
+// the size of their encoding differs from the decoded size; use EncodeC1 to re-encode them in the 8-bit form.
+func DecodeC1(b []byte) (Command, int) {
+	cmd, size, status := DecodeStatusC1(b)
+	if status != StatusOK {
+		return nil, 0
+	}
+	return cmd, size
+}
+
+// DecodeStatus is like Decode, but also reports whether b does not begin with a control sequence, begins with an
+// incomplete control sequence, or begins with a malformed control sequence. The returned size is non-zero only if
+// the status is StatusOK or StatusInvalid.
+func DecodeStatus(b []byte) (Command, int, Status) {
+	return decode(b, false)
+}
+
+// DecodeStatusC1 is like DecodeStatus, but also recognizes the 8-bit C1 control sequence introducer (0x9b). As with
+// DecodeC1, typed commands do not record the introducer.
+func DecodeStatusC1(b []byte) (Command, int, Status) {
+	return decode(b, true)
+}
+
+func decode(b []byte, c1 bool) (Command, int, Status) {
+	var introducerSize int
+	switch {
+	case len(b) >= 2 && b[0] == 0x1b && b[1] == '[':
+		introducerSize = 2
+	case c1 && len(b) >= 1 && b[0] == 0x9b:
+		introducerSize = 1
+	case len(b) == 1 && b[0] == 0x1b:
+		return nil, 0, StatusIncomplete
+	default:
+		return nil, 0, StatusNone
+	}
+	b = b[introducerSize:]
 
 	// parameter bytes
 	paramEnd := 0
@@ -58,81 +172,85 @@ func Decode(b []byte) (Command, int) {
 	intermediate, b := b[:intermediateEnd], b[intermediateEnd:]
 
 	// final byte
-	if len(b) < 1 || b[0] < 0x40 || b[0] > 0x7e {
-		return nil, 0
+	if len(b) < 1 {
+		return nil, 0, StatusIncomplete
+	}
+	if b[0] < 0x40 || b[0] > 0x7e {
+		return nil, introducerSize + len(params) + len(intermediate), StatusInvalid
 	}
 	final := b[0]
 
-	size := 2 + len(params) + len(intermediate) + 1
+	size := introducerSize + len(params) + len(intermediate) + 1
 	cmd, ok := decodeCommand(params, intermediate, final)
 	if !ok {
 		cmd = &ControlSequence{
 			Parameters:   params,
 			Intermediate: intermediate,
 			Final:        final,
+			C1:           introducerSize == 1,
 		}
 	}
-	return cmd, size
+	return cmd, size, StatusOK
 }
 
-func getCommand(intermediate []byte, final byte) (Command, bool) {
+func getCommand(private byte, intermediate []byte, final byte) (Command, bool) {
 	switch {
-	case len(intermediate) == 0:
+	case private == 0 && len(intermediate) == 0:
 		switch final {
 		case 0x40: // 8.3.64 ICH - INSERT CHARACTER (Pn)
-			return nil, false
+			return &InsertCharacter{}, true
 		case 0x41: // 8.3.22 CUU - CURSOR UP (Pn)
-			return nil, false
+			return &CursorUp{}, true
 		case 0x42: // 8.3.19 CUD - CURSOR DOWN (Pn)
-			return nil, false
+			return &CursorDown{}, true
 		case 0x43: // 8.3.20 CUF - CURSOR RIGHT (Pn)
-			return nil, false
+			return &CursorRight{}, true
 		case 0x44: // 8.3.18 CUB - CURSOR LEFT (Pn)
-			return nil, false
+			return &CursorLeft{}, true
 		case 0x45: // 8.3.12 CNL - CURSOR NEXT LINE (Pn)
-			return nil, false
+			return &CursorNextLine{}, true
 		case 0x46: // 8.3.13 CPL - CURSOR PRECEDING LINE (Pn)
-			return nil, false
+			return &CursorPrecedingLine{}, true
 		case 0x47: // 8.3.9 CHA - CURSOR CHARACTER ABSOLUTE (Pn)
-			return nil, false
+			return &CursorCharacterAbsolute{}, true
 		case 0x48: // 8.3.21 CUP - CURSOR POSITION (Pn1;Pn2)
-			return nil, false
+			return &CursorPosition{}, true
 		case 0x49: // 8.3.10 CHT - CURSOR FORWARD TABULATION (Pn)
-			return nil, false
+			return &CursorForwardTabulation{}, true
 		case 0x4a: // 8.3.39 ED - ERASE IN PAGE (Ps)
-			return nil, false
+			return &EraseInPage{}, true
 		case 0x4b: // 8.3.41 EL - ERASE IN LINE (Ps)
-			return nil, false
+			return &EraseInLine{}, true
 		case 0x4c: // 8.3.67 IL - INSERT LINE (Pn)
-			return nil, false
+			return &InsertLine{}, true
 		case 0x4d: // 8.3.32 DL - DELETE LINE (Pn)
-			return nil, false
+			return &DeleteLine{}, true
 		case 0x4e: // 8.3.40 EF - ERASE IN FIELD (Ps)
-			return nil, false
+			return &EraseInField{}, true
 		case 0x4f: // 8.3.37 EA - ERASE IN AREA (Ps)
-			return nil, false
+			return &EraseInArea{}, true
 		case 0x50: // 8.3.26 DCH - DELETE CHARACTER (Pn)
-			return nil, false
+			return &DeleteCharacter{}, true
 		case 0x51: // 8.3.115 SEE - SELECT EDITING EXTENT (Ps)
 			return nil, false
 		case 0x52: // 8.3.14 CPR - ACTIVE POSITION REPORT (Pn1;Pn2)
-			return nil, false
+			return &CursorPositionReport{}, true
 		case 0x53: // 8.3.147 SU - SCROLL UP (Pn)
-			return nil, false
+			return &ScrollUp{}, true
 		case 0x54: // 8.3.113 SD - SCROLL DOWN (Pn)
-			return nil, false
+			return &ScrollDown{}, true
 		case 0x55: // 8.3.87 NP - NEXT PAGE (Pn)
 			return nil, false
 		case 0x56: // 8.3.95 PP - PRECEDING PAGE (Pn)
 			return nil, false
 		case 0x57: // 8.3.17 CTC - CURSOR TABULATION CONTROL (Ps...)
-			return nil, false
+			return &CursorTabulationControl{}, true
 		case 0x58: // 8.3.38 ECH - ERASE CHARACTER (Pn)
-			return nil, false
+			return &EraseCharacter{}, true
 		case 0x59: // 8.3.23 CVT - CURSOR LINE TABULATION (Pn)
-			return nil, false
+			return &CursorLineTabulation{}, true
 		case 0x5a: // 8.3.7 CBT - CURSOR BACKWARD TABULATION (Pn)
-			return nil, false
+			return &CursorBackwardTabulation{}, true
 		case 0x5b: // 8.3.137 SRS - START REVERSED STRING (Ps)
 			return nil, false
 		case 0x5c: // 8.3.99 PTX - PARALLEL TEXTS (Ps)
@@ -142,44 +260,46 @@ func getCommand(intermediate []byte, final byte) (Command, bool) {
 		case 0x5e: // 8.3.120 SIMD - SELECT IMPLICIT MOVEMENT DIRECTION (Ps)
 			return nil, false
 		case 0x60: // 8.3.57 HPA - CHARACTER POSITION ABSOLUTE (Pn)
-			return nil, false
+			return &CharacterPositionAbsolute{}, true
 		case 0x61: // 8.3.59 HPR - CHARACTER POSITION FORWARD (Pn)
-			return nil, false
+			return &CharacterPositionForward{}, true
 		case 0x62: // 8.3.103 REP - REPEAT (Pn)
-			return nil, false
+			return &Repeat{}, true
 		case 0x63: // 8.3.24 DA - DEVICE ATTRIBUTES (Ps)
-			return nil, false
+			return &PrimaryDeviceAttributes{}, true
 		case 0x64: // 8.3.158 VPA - LINE POSITION ABSOLUTE (Pn)
-			return nil, false
+			return &LinePositionAbsolute{}, true
 		case 0x65: // 8.3.160 VPR - LINE POSITION FORWARD (Pn)
-			return nil, false
+			return &LinePositionForward{}, true
 		case 0x66: // 8.3.63 HVP - CHARACTER AND LINE POSITION (Pn1;Pn2)
-			return nil, false
+			return &CharacterAndLinePosition{}, true
 		case 0x67: // 8.3.154 TBC - TABULATION CLEAR (Ps)
-			return nil, false
+			return &TabulationClear{}, true
 		case 0x68: // 8.3.125 SM - SET MODE (Ps...)
-			return nil, false
+			return &SetMode{}, true
 		case 0x69: // 8.3.82 MC - MEDIA COPY (Ps)
 			return nil, false
 		case 0x6a: // 8.3.58 HPB - CHARACTER POSITION BACKWARD (Pn)
-			return nil, false
+			return &CharacterPositionBackward{}, true
 		case 0x6b: // 8.3.159 VPB - LINE POSITION BACKWARD (Pn)
-			return nil, false
+			return &LinePositionBackward{}, true
 		case 0x6c: // 8.3.106 RM - RESET MODE (Ps...)
-			return nil, false
+			return &ResetMode{}, true
 		case 0x6d: // 8.3.117 SGR - SELECT GRAPHIC RENDITION (Ps...)
-			return &SetGraphicsRendition{}, true
+			return &SGRList{}, true
 		case 0x6e: // 8.3.35 DSR - DEVICE STATUS REPORT (Ps)
-			return nil, false
+			return &DeviceStatusReport{}, true
 		case 0x6f: // 8.3.25 DAQ - DEFINE AREA QUALIFICATION (Ps...)
 			return nil, false
+		case 0x72: // DECSTBM - SET TOP AND BOTTOM MARGINS (Pn1;Pn2)
+			return &SetTopAndBottomMargins{}, true
 		}
-	case len(intermediate) == 1 && intermediate[0] == 0x20:
+	case private == 0 && len(intermediate) == 1 && intermediate[0] == 0x20:
 		switch final {
 		case 0x40: // 8.3.121 SL - SCROLL LEFT (Pn)
-			return nil, false
+			return &ScrollLeft{}, true
 		case 0x41: // 8.3.135 SR - SCROLL RIGHT (Pn)
-			return nil, false
+			return &ScrollRight{}, true
 		case 0x42: // 8.3.55 GSM - GRAPHIC SIZE MODIFICATION (Pn1;Pn2)
 			return nil, false
 		case 0x43: // 8.3.56 GSS - GRAPHIC SIZE SELECTION (Pn)
@@ -263,58 +383,159 @@ func getCommand(intermediate []byte, final byte) (Command, bool) {
 		case 0x6b: // 8.3.111 SCP - SELECT CHARACTER PATH (Ps1;Ps2)
 			return nil, false
 		}
+	case private == '?' && len(intermediate) == 0:
+		switch final {
+		case 0x63: // DA1 - PRIMARY DEVICE ATTRIBUTES report (Ps...)
+			return &PrimaryDeviceAttributesReport{}, true
+		case 0x68: // DECSET - DEC PRIVATE MODE SET (Ps...)
+			return &SetPrivateMode{}, true
+		case 0x6c: // DECRST - DEC PRIVATE MODE RESET (Ps...)
+			return &ResetPrivateMode{}, true
+		}
+	case private == '>' && len(intermediate) == 0:
+		switch final {
+		case 0x63: // DA2 - SECONDARY DEVICE ATTRIBUTES (Ps) or report (Pp;Pv;Pc)
+			return &secondaryDeviceAttributesSequence{}, true
+		}
+	case private == '=' && len(intermediate) == 0:
+		switch final {
+		case 0x63: // DA3 - TERTIARY DEVICE ATTRIBUTES (Ps)
+			return &TertiaryDeviceAttributes{}, true
+		}
 	}
 	return nil, false
 }
 
 func decodeCommand(parameters, intermediate []byte, final byte) (Command, bool) {
-	cmd, ok := getCommand(intermediate, final)
+	private := privateMarker(parameters)
+	if private != 0 {
+		parameters = parameters[1:]
+	}
+
+	cmd, ok := getCommand(private, intermediate, final)
 	if !ok {
 		return nil, false
 	}
 
-	// Decode the parameter list.
-	var param []byte
-	var params []int
-	for rawParams := parameters; len(rawParams) > 0; rawParams = rawParams[1:] {
-		if rawParams[0] == ';' {
-			if len(param) == 0 {
-				params = append(params, -1)
-			} else {
-				i, err := strconv.ParseUint(string(param), 10, 0)
-				if err != nil {
-					return nil, false
-				}
-				param, params = param[:0], append(params, int(i))
+	params, ok := decodeParameterList(parameters)
+	if !ok {
+		return nil, false
+	}
+	if d, isSubParameterDecoder := cmd.(subParameterDecoder); isSubParameterDecoder {
+		ok = d.decodeSubParameters(params)
+	} else {
+		flat := make([]int, len(params))
+		for i, p := range params {
+			if len(p) != 1 {
+				return nil, false
 			}
-		} else {
-			param = append(param, rawParams[0])
+			flat[i] = p[0]
 		}
+		ok = cmd.decodeParameters(flat)
 	}
-	if len(param) != 0 {
-		i, err := strconv.ParseUint(string(param), 10, 0)
-		if err != nil {
-			return nil, false
+	if !ok {
+		return nil, false
+	}
+
+	// SGR sequences that affect at most one graphics rendition aspect are returned as a *SetGraphicsRendition.
+	if list, ok := cmd.(*SGRList); ok && len(*list) <= 1 {
+		sgr := &SetGraphicsRendition{}
+		if len(*list) == 1 {
+			sgr = &(*list)[0]
 		}
-		param, params = param[:0], append(params, int(i))
+		return sgr, true
 	}
-	if len(parameters) != 0 && parameters[len(parameters)-1] == ';' {
-		params = append(params, -1)
+
+	if r, ok := cmd.(commandResolver); ok {
+		return r.resolveCommand(), true
 	}
+	return cmd, true
+}
+
+// subParameterDecoder is implemented by commands whose parameters may contain colon-delimited sub-parameters as
+// described in ITU T.416.
+type subParameterDecoder interface {
+	decodeSubParameters(params [][]int) bool
+}
 
-	return cmd, cmd.decodeParameters(params)
+// commandResolver is implemented by commands that stand in for one of several commands that share a control
+// sequence's private marker, intermediate bytes, and final byte. Once its parameters have been decoded, a
+// commandResolver returns the command that the control sequence represents.
+type commandResolver interface {
+	resolveCommand() Command
+}
+
+// decodeParameterList decodes a list of semicolon-delimited parameters, each of which is a list of colon-delimited
+// sub-parameters. Omitted parameters and sub-parameters are decoded as -1.
+func decodeParameterList(parameters []byte) ([][]int, bool) {
+	if len(parameters) == 0 {
+		return nil, true
+	}
+
+	var params [][]int
+	for _, rawParam := range bytes.Split(parameters, []byte{';'}) {
+		var param []int
+		for _, rawSubParam := range bytes.Split(rawParam, []byte{':'}) {
+			if len(rawSubParam) == 0 {
+				param = append(param, -1)
+				continue
+			}
+			i, err := strconv.ParseUint(string(rawSubParam), 10, 0)
+			if err != nil {
+				return nil, false
+			}
+			param = append(param, int(i))
+		}
+		params = append(params, param)
+	}
+	return params, true
+}
+
+// decodePn decodes the parameters of a control function that takes len(values) numeric parameters. Each parameter
+// defaults to 1 if it is omitted or zero.
+func decodePn(params []int, values ...*int) bool {
+	if len(params) > len(values) {
+		return false
+	}
+	for i, v := range values {
+		*v = 1
+		if i < len(params) && params[i] > 0 {
+			*v = params[i]
+		}
+	}
+	return true
+}
+
+// decodePs decodes the parameters of a control function that takes len(values) selective parameters. Each parameter
+// defaults to 0 if it is omitted.
+func decodePs(params []int, values ...*int) bool {
+	if len(params) > len(values) {
+		return false
+	}
+	for i, v := range values {
+		*v = 0
+		if i < len(params) && params[i] >= 0 {
+			*v = params[i]
+		}
+	}
+	return true
 }
 
 func encodeCommand(w io.Writer, parameters []int, intermediate []byte, final byte) (int, error) {
+	return encodePrivateCommand(w, 0, parameters, intermediate, final)
+}
+
+func encodePrivateCommand(w io.Writer, private byte, parameters []int, intermediate []byte, final byte) (int, error) {
 	// Encode the parameter list.
 	var params bytes.Buffer
+	if private != 0 {
+		params.WriteByte(private)
+	}
 	for i, p := range parameters {
 		if i > 0 {
 			params.WriteByte(';')
 		}
-		if p >= 0 {
-			params.WriteString(strconv.FormatUint(uint64(p), 10))
-		}
+		writeParameter(&params, p)
 	}
 
 	// Write the CSI + control sequence.
@@ -325,3 +546,10 @@ func encodeCommand(w io.Writer, parameters []int, intermediate []byte, final byt
 	}
 	return cs.Encode(w)
 }
+
+// writeParameter writes a single parameter value. Negative values represent omitted parameters and are not written.
+func writeParameter(params *bytes.Buffer, p int) {
+	if p >= 0 {
+		params.WriteString(strconv.FormatUint(uint64(p), 10))
+	}
+}
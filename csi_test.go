@@ -56,3 +56,143 @@ func TestCSI(t *testing.T) {
 	}
 	assert.Equal(t, input, buf.Bytes())
 }
+
+func TestDecodeC1(t *testing.T) {
+	cmd, size := Decode([]byte("\x9b1m"))
+	assert.Nil(t, cmd)
+	assert.Equal(t, 0, size)
+
+	cmd, size = DecodeC1([]byte("\x9b1mHello"))
+	assert.Equal(t, 3, size)
+	assert.Equal(t, &SetGraphicsRendition{Command: SGRBold, Parameters: []int{}}, cmd)
+
+	cmd, size = DecodeC1([]byte("\x1b[2J"))
+	assert.Equal(t, 4, size)
+	assert.Equal(t, &EraseInPage{Mode: EraseAll}, cmd)
+
+	command := []byte("\x9b1 ~")
+	cmd, size = DecodeC1(command)
+	assert.Equal(t, 4, size)
+	assert.Equal(t, &ControlSequence{Parameters: []byte("1"), Intermediate: []byte(" "), Final: '~', C1: true}, cmd)
+
+	var b bytes.Buffer
+	encodedSize, err := cmd.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+func TestDecodeC1_TypedCommand(t *testing.T) {
+	command := []byte("\x9b1m")
+	cmd, size := DecodeC1(command)
+	assert.Equal(t, 3, size)
+	assert.Equal(t, &SetGraphicsRendition{Command: SGRBold, Parameters: []int{}}, cmd)
+
+	// Typed commands encode with ESC [ by default...
+	var b bytes.Buffer
+	encodedSize, err := cmd.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, encodedSize)
+	assert.Equal(t, "\x1b[1m", b.String())
+
+	// ...and must be re-encoded with EncodeC1 to preserve the 8-bit form.
+	b.Reset()
+	encodedSize, err = EncodeC1(&b, cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+func TestEncodeC1(t *testing.T) {
+	var b bytes.Buffer
+	size, err := EncodeC1(&b, &CursorPosition{Row: 3, Column: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, "\x9b3;4H", b.String())
+
+	cmd, decodedSize := DecodeC1(b.Bytes())
+	assert.Equal(t, size, decodedSize)
+	assert.Equal(t, &CursorPosition{Row: 3, Column: 4}, cmd)
+}
+
+func TestDecodeStatus(t *testing.T) {
+	cases := []struct {
+		input  string
+		cmd    Command
+		size   int
+		status Status
+	}{
+		{input: "hello", status: StatusNone},
+		{input: "", status: StatusNone},
+		{input: "\x1bX", status: StatusNone},
+		{input: "\x1b", status: StatusIncomplete},
+		{input: "\x1b[", status: StatusIncomplete},
+		{input: "\x1b[1;3", status: StatusIncomplete},
+		{input: "\x1b[?25", status: StatusIncomplete},
+		{input: "\x1b[1 ", status: StatusIncomplete},
+		{input: "\x1b[1\n", size: 3, status: StatusInvalid},
+		{input: "\x1b[1 2m", size: 4, status: StatusInvalid},
+		{input: "\x1b[\x7f", size: 2, status: StatusInvalid},
+		{input: "\x1b[2Jabc", cmd: &EraseInPage{Mode: EraseAll}, size: 4, status: StatusOK},
+	}
+	for _, c := range cases {
+		cmd, size, status := DecodeStatus([]byte(c.input))
+		assert.Equal(t, c.cmd, cmd, c.input)
+		assert.Equal(t, c.size, size, c.input)
+		assert.Equal(t, c.status, status, c.input)
+
+		cmd, size = Decode([]byte(c.input))
+		if c.status != StatusOK {
+			assert.Nil(t, cmd)
+			assert.Equal(t, 0, size)
+		}
+	}
+
+	_, size, status := DecodeStatus([]byte("\x9b"))
+	assert.Equal(t, 0, size)
+	assert.Equal(t, StatusNone, status)
+
+	_, size, status = DecodeStatusC1([]byte("\x9b12"))
+	assert.Equal(t, 0, size)
+	assert.Equal(t, StatusIncomplete, status)
+}
+
+// roundTripCase describes a control sequence that decodes to cmd and encodes as encoded. If encoded is empty, the
+// command encodes as the original sequence.
+type roundTripCase struct {
+	command string
+	cmd     Command
+	encoded string
+}
+
+// assertRoundTrip checks that each case decodes to the expected command and that the command encodes as expected.
+func assertRoundTrip(t *testing.T, cases []roundTripCase) {
+	for _, c := range cases {
+		t.Run(c.command[1:], func(t *testing.T) {
+			cmd, size := Decode([]byte(c.command))
+			assert.Equal(t, len(c.command), size)
+			assert.Equal(t, c.cmd, cmd)
+
+			encoded := c.encoded
+			if encoded == "" {
+				encoded = c.command
+			}
+
+			var b bytes.Buffer
+			encodedSize, err := cmd.Encode(&b)
+			assert.NoError(t, err)
+			assert.Equal(t, len(encoded), encodedSize)
+			assert.Equal(t, encoded, b.String())
+		})
+	}
+}
+
+// assertUnrecognized checks that each command decodes as a generic *ControlSequence.
+func assertUnrecognized(t *testing.T, commands ...string) {
+	for _, command := range commands {
+		cmd, size := Decode([]byte(command))
+		_, ok := cmd.(*ControlSequence)
+		assert.True(t, ok, command)
+		assert.Equal(t, len(command), size)
+	}
+}
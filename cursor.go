@@ -0,0 +1,283 @@
+package ansicsi
+
+import "io"
+
+// CursorUp represents a CURSOR UP (CUU) control function, which moves the active position up by the given number of
+// lines.
+type CursorUp struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorUp) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x41)
+}
+
+func (c *CursorUp) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorDown represents a CURSOR DOWN (CUD) control function, which moves the active position down by the given
+// number of lines.
+type CursorDown struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorDown) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x42)
+}
+
+func (c *CursorDown) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorRight represents a CURSOR RIGHT (CUF) control function, which moves the active position forward by the given
+// number of character positions.
+type CursorRight struct {
+	// N is the number of character positions to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorRight) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x43)
+}
+
+func (c *CursorRight) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorLeft represents a CURSOR LEFT (CUB) control function, which moves the active position backward by the given
+// number of character positions.
+type CursorLeft struct {
+	// N is the number of character positions to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorLeft) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x44)
+}
+
+func (c *CursorLeft) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorNextLine represents a CURSOR NEXT LINE (CNL) control function, which moves the active position to the first
+// character position of the line the given number of lines down.
+type CursorNextLine struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorNextLine) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x45)
+}
+
+func (c *CursorNextLine) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorPrecedingLine represents a CURSOR PRECEDING LINE (CPL) control function, which moves the active position to
+// the first character position of the line the given number of lines up.
+type CursorPrecedingLine struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorPrecedingLine) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x46)
+}
+
+func (c *CursorPrecedingLine) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorCharacterAbsolute represents a CURSOR CHARACTER ABSOLUTE (CHA) control function, which moves the active
+// position to the given character position of the current line.
+type CursorCharacterAbsolute struct {
+	// Column is the 1-based character position. Defaults to 1.
+	Column int
+}
+
+func (c *CursorCharacterAbsolute) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Column}, nil, 0x47)
+}
+
+func (c *CursorCharacterAbsolute) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Column)
+}
+
+// CursorPosition represents a CURSOR POSITION (CUP) control function, which moves the active position to the given
+// line and character position.
+type CursorPosition struct {
+	// Row is the 1-based line. Defaults to 1.
+	Row int
+	// Column is the 1-based character position. Defaults to 1.
+	Column int
+}
+
+func (c *CursorPosition) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Row, c.Column}, nil, 0x48)
+}
+
+func (c *CursorPosition) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Row, &c.Column)
+}
+
+// CharacterAndLinePosition represents a CHARACTER AND LINE POSITION (HVP) control function, which moves the active
+// position to the given line and character position. Terminals treat HVP identically to CUP.
+type CharacterAndLinePosition struct {
+	// Row is the 1-based line. Defaults to 1.
+	Row int
+	// Column is the 1-based character position. Defaults to 1.
+	Column int
+}
+
+func (c *CharacterAndLinePosition) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Row, c.Column}, nil, 0x66)
+}
+
+func (c *CharacterAndLinePosition) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Row, &c.Column)
+}
+
+// CharacterPositionAbsolute represents a CHARACTER POSITION ABSOLUTE (HPA) control function, which moves the active
+// position to the given character position of the current line.
+type CharacterPositionAbsolute struct {
+	// Column is the 1-based character position. Defaults to 1.
+	Column int
+}
+
+func (c *CharacterPositionAbsolute) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Column}, nil, 0x60)
+}
+
+func (c *CharacterPositionAbsolute) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Column)
+}
+
+// CharacterPositionForward represents a CHARACTER POSITION FORWARD (HPR) control function, which moves the active
+// position forward by the given number of character positions.
+type CharacterPositionForward struct {
+	// N is the number of character positions to move. Defaults to 1.
+	N int
+}
+
+func (c *CharacterPositionForward) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x61)
+}
+
+func (c *CharacterPositionForward) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CharacterPositionBackward represents a CHARACTER POSITION BACKWARD (HPB) control function, which moves the active
+// position backward by the given number of character positions.
+type CharacterPositionBackward struct {
+	// N is the number of character positions to move. Defaults to 1.
+	N int
+}
+
+func (c *CharacterPositionBackward) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x6a)
+}
+
+func (c *CharacterPositionBackward) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// LinePositionAbsolute represents a LINE POSITION ABSOLUTE (VPA) control function, which moves the active position to
+// the given line without changing the character position.
+type LinePositionAbsolute struct {
+	// Row is the 1-based line. Defaults to 1.
+	Row int
+}
+
+func (c *LinePositionAbsolute) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Row}, nil, 0x64)
+}
+
+func (c *LinePositionAbsolute) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Row)
+}
+
+// LinePositionForward represents a LINE POSITION FORWARD (VPR) control function, which moves the active position down
+// by the given number of lines.
+type LinePositionForward struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *LinePositionForward) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x65)
+}
+
+func (c *LinePositionForward) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// LinePositionBackward represents a LINE POSITION BACKWARD (VPB) control function, which moves the active position up
+// by the given number of lines.
+type LinePositionBackward struct {
+	// N is the number of lines to move. Defaults to 1.
+	N int
+}
+
+func (c *LinePositionBackward) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x6b)
+}
+
+func (c *LinePositionBackward) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// ResolvePosition returns the position of the cursor after the given cursor positioning command is applied on a
+// screen with the given number of columns and lines. Positions are 0-based, and the result is clamped to the screen.
+// ResolvePosition returns false if cmd does not position the cursor.
+//
+// The positioning commands are CursorUp, CursorDown, CursorRight, CursorLeft, CursorNextLine, CursorPrecedingLine,
+// CursorCharacterAbsolute, CursorPosition, CharacterAndLinePosition, CharacterPositionAbsolute,
+// CharacterPositionForward, CharacterPositionBackward, LinePositionAbsolute, LinePositionForward, and
+// LinePositionBackward.
+func ResolvePosition(cmd Command, row, column, width, height int) (int, int, bool) {
+	// Counts and positions are clamped to the size of the screen before they are used so that the arithmetic below
+	// cannot overflow.
+	rows := func(n int) int { return clamp(n, -height, height) }
+	columns := func(n int) int { return clamp(n, -width, width) }
+
+	switch cmd := cmd.(type) {
+	case *CursorUp:
+		row -= rows(cmd.N)
+	case *CursorDown:
+		row += rows(cmd.N)
+	case *CursorRight:
+		column += columns(cmd.N)
+	case *CursorLeft:
+		column -= columns(cmd.N)
+	case *CursorNextLine:
+		row, column = row+rows(cmd.N), 0
+	case *CursorPrecedingLine:
+		row, column = row-rows(cmd.N), 0
+	case *CursorCharacterAbsolute:
+		column = columns(cmd.Column) - 1
+	case *CursorPosition:
+		row, column = rows(cmd.Row)-1, columns(cmd.Column)-1
+	case *CharacterAndLinePosition:
+		row, column = rows(cmd.Row)-1, columns(cmd.Column)-1
+	case *CharacterPositionAbsolute:
+		column = columns(cmd.Column) - 1
+	case *CharacterPositionForward:
+		column += columns(cmd.N)
+	case *CharacterPositionBackward:
+		column -= columns(cmd.N)
+	case *LinePositionAbsolute:
+		row = rows(cmd.Row) - 1
+	case *LinePositionForward:
+		row += rows(cmd.N)
+	case *LinePositionBackward:
+		row -= rows(cmd.N)
+	default:
+		return row, column, false
+	}
+	return clamp(row, 0, height-1), clamp(column, 0, width-1), true
+}
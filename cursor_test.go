@@ -0,0 +1,113 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[5A", cmd: &CursorUp{N: 5}},
+		{command: "\x1b[A", cmd: &CursorUp{N: 1}, encoded: "\x1b[1A"},
+		{command: "\x1b[0A", cmd: &CursorUp{N: 1}, encoded: "\x1b[1A"},
+		{command: "\x1b[2B", cmd: &CursorDown{N: 2}},
+		{command: "\x1b[12C", cmd: &CursorRight{N: 12}},
+		{command: "\x1b[3D", cmd: &CursorLeft{N: 3}},
+		{command: "\x1b[4E", cmd: &CursorNextLine{N: 4}},
+		{command: "\x1b[E", cmd: &CursorNextLine{N: 1}, encoded: "\x1b[1E"},
+		{command: "\x1b[6F", cmd: &CursorPrecedingLine{N: 6}},
+		{command: "\x1b[40G", cmd: &CursorCharacterAbsolute{Column: 40}},
+		{command: "\x1b[10;20H", cmd: &CursorPosition{Row: 10, Column: 20}},
+		{command: "\x1b[H", cmd: &CursorPosition{Row: 1, Column: 1}, encoded: "\x1b[1;1H"},
+		{command: "\x1b[;5H", cmd: &CursorPosition{Row: 1, Column: 5}, encoded: "\x1b[1;5H"},
+		{command: "\x1b[7H", cmd: &CursorPosition{Row: 7, Column: 1}, encoded: "\x1b[7;1H"},
+		{command: "\x1b[3;4f", cmd: &CharacterAndLinePosition{Row: 3, Column: 4}},
+		{command: "\x1b[5`", cmd: &CharacterPositionAbsolute{Column: 5}},
+		{command: "\x1b[`", cmd: &CharacterPositionAbsolute{Column: 1}, encoded: "\x1b[1`"},
+		{command: "\x1b[2a", cmd: &CharacterPositionForward{N: 2}},
+		{command: "\x1b[3j", cmd: &CharacterPositionBackward{N: 3}},
+		{command: "\x1b[10d", cmd: &LinePositionAbsolute{Row: 10}},
+		{command: "\x1b[4e", cmd: &LinePositionForward{N: 4}},
+		{command: "\x1b[0e", cmd: &LinePositionForward{N: 1}, encoded: "\x1b[1e"},
+		{command: "\x1b[6k", cmd: &LinePositionBackward{N: 6}},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestCursor_TooManyParameters(t *testing.T) {
+	assertUnrecognized(t, "\x1b[1;2A", "\x1b[1;2;3H", "\x1b[1;2d", "\x1b[1;2`")
+}
+
+func TestResolvePosition(t *testing.T) {
+	cases := []struct {
+		cmd         Command
+		row, column int
+	}{
+		{cmd: &CursorUp{N: 2}, row: 3, column: 10},
+		{cmd: &CursorUp{N: 9}, row: 0, column: 10},
+		{cmd: &CursorDown{N: 2}, row: 7, column: 10},
+		{cmd: &CursorDown{N: 99}, row: 23, column: 10},
+		{cmd: &CursorRight{N: 5}, row: 5, column: 15},
+		{cmd: &CursorRight{N: 99}, row: 5, column: 79},
+		{cmd: &CursorLeft{N: 5}, row: 5, column: 5},
+		{cmd: &CursorLeft{N: 99}, row: 5, column: 0},
+		{cmd: &CursorNextLine{N: 1}, row: 6, column: 0},
+		{cmd: &CursorPrecedingLine{N: 2}, row: 3, column: 0},
+		{cmd: &CursorCharacterAbsolute{Column: 1}, row: 5, column: 0},
+		{cmd: &CursorPosition{Row: 1, Column: 1}, row: 0, column: 0},
+		{cmd: &CursorPosition{Row: 99, Column: 99}, row: 23, column: 79},
+		{cmd: &CharacterAndLinePosition{Row: 2, Column: 3}, row: 1, column: 2},
+		{cmd: &CharacterPositionAbsolute{Column: 20}, row: 5, column: 19},
+		{cmd: &CharacterPositionForward{N: 3}, row: 5, column: 13},
+		{cmd: &CharacterPositionBackward{N: 3}, row: 5, column: 7},
+		{cmd: &LinePositionAbsolute{Row: 12}, row: 11, column: 10},
+		{cmd: &LinePositionForward{N: 3}, row: 8, column: 10},
+		{cmd: &LinePositionBackward{N: 3}, row: 2, column: 10},
+	}
+	for _, c := range cases {
+		row, column, ok := ResolvePosition(c.cmd, 5, 10, 80, 24)
+		if assert.True(t, ok, "%#v", c.cmd) {
+			assert.Equal(t, c.row, row, "%#v", c.cmd)
+			assert.Equal(t, c.column, column, "%#v", c.cmd)
+		}
+	}
+
+	row, column, ok := ResolvePosition(&EraseInLine{}, 5, 10, 80, 24)
+	assert.False(t, ok)
+	assert.Equal(t, 5, row)
+	assert.Equal(t, 10, column)
+}
+
+func TestResolvePosition_LargeCounts(t *testing.T) {
+	const maxInt = int(^uint(0) >> 1)
+	const minInt = -maxInt - 1
+
+	cases := []struct {
+		cmd         Command
+		row, column int
+	}{
+		{cmd: &CursorUp{N: maxInt}, row: 0, column: 5},
+		{cmd: &CursorDown{N: maxInt}, row: 9, column: 5},
+		{cmd: &CursorRight{N: maxInt}, row: 5, column: 9},
+		{cmd: &CursorLeft{N: maxInt}, row: 5, column: 0},
+		{cmd: &CursorNextLine{N: maxInt}, row: 9, column: 0},
+		{cmd: &CursorPrecedingLine{N: maxInt}, row: 0, column: 0},
+		{cmd: &CursorUp{N: minInt}, row: 9, column: 5},
+		{cmd: &CursorLeft{N: minInt}, row: 5, column: 9},
+		{cmd: &CursorPosition{Row: maxInt, Column: maxInt}, row: 9, column: 9},
+		{cmd: &CursorPosition{Row: minInt, Column: minInt}, row: 0, column: 0},
+		{cmd: &CharacterPositionForward{N: maxInt}, row: 5, column: 9},
+		{cmd: &CharacterPositionBackward{N: minInt}, row: 5, column: 9},
+		{cmd: &LinePositionAbsolute{Row: minInt}, row: 0, column: 5},
+		{cmd: &LinePositionForward{N: maxInt}, row: 9, column: 5},
+		{cmd: &LinePositionBackward{N: maxInt}, row: 0, column: 5},
+	}
+	for _, c := range cases {
+		row, column, ok := ResolvePosition(c.cmd, 5, 5, 10, 10)
+		if assert.True(t, ok, "%#v", c.cmd) {
+			assert.Equal(t, c.row, row, "%#v", c.cmd)
+			assert.Equal(t, c.column, column, "%#v", c.cmd)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package ansicsi
+
+import "io"
+
+// ColorDepth describes the colors that a terminal is able to display.
+type ColorDepth int
+
+const (
+	ColorDepthMonochrome ColorDepth = iota // No colors
+	ColorDepth16                           // The 8 basic and 8 bright ANSI colors
+	ColorDepth256                          // The 256-color palette
+	ColorDepthTrueColor                    // 24-bit colors
+)
+
+// Downgrade returns the color that most closely approximates c at the given depth. 24-bit colors are mapped to the
+// nearest color of the 6x6x6 color cube or grayscale ramp of the 256-color palette, and palette colors are mapped to
+// the nearest of the 16 ANSI colors. At ColorDepthMonochrome, all colors are mapped to the default color.
+func (c Color) Downgrade(depth ColorDepth) Color {
+	switch {
+	case depth == ColorDepthMonochrome:
+		return Color{}
+	case depth == ColorDepth256 && c.kind == ColorRGB:
+		return IndexedColor(uint8(nearestPaletteColor(c, 16, 256)))
+	case depth == ColorDepth16 && c.kind == ColorIndexed && c.index < 16:
+		return ANSIColor(int(c.index))
+	case depth == ColorDepth16 && (c.kind == ColorIndexed || c.kind == ColorRGB):
+		return ANSIColor(nearestPaletteColor(c, 0, 16))
+	default:
+		return c
+	}
+}
+
+// nearestPaletteColor returns the index in [start, end) of the palette color that is nearest to c.
+func nearestPaletteColor(c Color, start, end int) int {
+	r, g, b := c.RGB()
+
+	best, bestDistance := start, -1
+	for i := start; i < end; i++ {
+		pr, pg, pb := paletteRGB(i)
+		dr, dg, db := int(r)-int(pr), int(g)-int(pg), int(b)-int(pb)
+		if distance := dr*dr + dg*dg + db*db; bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return best
+}
+
+// DowngradeCommand rewrites the colors selected by a *SetGraphicsRendition or *SGRList command to the given depth
+// using Color.Downgrade. If the command does not need to be rewritten, it is returned unchanged. If the command only
+// selects colors and depth is ColorDepthMonochrome, DowngradeCommand returns nil. All other commands are returned
+// unchanged.
+func DowngradeCommand(cmd Command, depth ColorDepth) Command {
+	switch cmd := cmd.(type) {
+	case *SetGraphicsRendition:
+		if sgr := downgradeSGR(cmd, depth); sgr != nil {
+			return sgr
+		}
+		return nil
+	case *SGRList:
+		var list SGRList
+		changed := false
+		for i := range *cmd {
+			sgr := &(*cmd)[i]
+			downgraded := downgradeSGR(sgr, depth)
+			if downgraded != nil {
+				list = append(list, *downgraded)
+			}
+			changed = changed || downgraded != sgr
+		}
+		switch {
+		case !changed:
+			return cmd
+		case len(list) == 0:
+			return nil
+		default:
+			return &list
+		}
+	default:
+		return cmd
+	}
+}
+
+// downgradeSGR rewrites the color selected by sgr to the given depth. It returns sgr if no rewrite is necessary, or nil
+// if the command should be removed.
+func downgradeSGR(sgr *SetGraphicsRendition, depth ColorDepth) *SetGraphicsRendition {
+	c, ok := sgr.Color()
+	if !ok {
+		return sgr
+	}
+	if depth == ColorDepthMonochrome {
+		return nil
+	}
+
+	downgraded := c.Downgrade(depth)
+	if downgraded == c {
+		return sgr
+	}
+	// Only extended colors are rewritten: ANSI and default colors are available at every depth except monochrome.
+	switch sgr.Command {
+	case SGRForegroundColor:
+		return ForegroundColor(downgraded)
+	case SGRBackgroundColor:
+		return BackgroundColor(downgraded)
+	default:
+		return UnderlineColor(downgraded)
+	}
+}
+
+// ColorDepthWriter is an io.Writer that rewrites the colors selected by Set Graphics Rendition control functions to
+// a given depth before forwarding its input to an underlying writer. See DowngradeCommand for details. Text and all
+// other control sequences are forwarded unchanged.
+type ColorDepthWriter struct {
+	w        io.Writer
+	depth    ColorDepth
+	splitter sequenceSplitter
+}
+
+// NewColorDepthWriter returns a ColorDepthWriter that writes to w.
+func NewColorDepthWriter(w io.Writer, depth ColorDepth) *ColorDepthWriter {
+	return &ColorDepthWriter{w: w, depth: depth}
+}
+
+// Write writes b to the underlying writer, rewriting colors as necessary.
+func (c *ColorDepthWriter) Write(b []byte) (int, error) {
+	return c.splitter.split(b, c)
+}
+
+// Flush writes any buffered incomplete control sequence to the underlying writer.
+func (c *ColorDepthWriter) Flush() error {
+	return c.splitter.flush(c)
+}
+
+func (c *ColorDepthWriter) handleText(text []byte) error {
+	_, err := c.w.Write(text)
+	return err
+}
+
+func (c *ColorDepthWriter) handleCommand(cmd Command, raw []byte) error {
+	switch downgraded := DowngradeCommand(cmd, c.depth); downgraded {
+	case nil:
+		return nil
+	case cmd:
+		_, err := c.w.Write(raw)
+		return err
+	default:
+		_, err := downgraded.Encode(c.w)
+		return err
+	}
+}
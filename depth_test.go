@@ -0,0 +1,81 @@
+package ansicsi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColor_Downgrade(t *testing.T) {
+	cases := []struct {
+		color    Color
+		depth    ColorDepth
+		expected Color
+	}{
+		{color: RGBColor(1, 2, 3), depth: ColorDepthTrueColor, expected: RGBColor(1, 2, 3)},
+		{color: RGBColor(255, 0, 0), depth: ColorDepth256, expected: IndexedColor(196)},
+		{color: RGBColor(0x5f, 0x87, 0xaf), depth: ColorDepth256, expected: IndexedColor(67)},
+		{color: RGBColor(0x80, 0x80, 0x81), depth: ColorDepth256, expected: IndexedColor(244)},
+		{color: RGBColor(250, 5, 5), depth: ColorDepth16, expected: BrightColor(1)},
+		{color: RGBColor(200, 0, 0), depth: ColorDepth16, expected: ANSIColor(1)},
+		{color: IndexedColor(12), depth: ColorDepth16, expected: BrightColor(4)},
+		{color: IndexedColor(46), depth: ColorDepth16, expected: BrightColor(2)},
+		{color: IndexedColor(236), depth: ColorDepth16, expected: ANSIColor(0)},
+		{color: IndexedColor(46), depth: ColorDepth256, expected: IndexedColor(46)},
+		{color: ANSIColor(3), depth: ColorDepth16, expected: ANSIColor(3)},
+		{color: ANSIColor(3), depth: ColorDepthMonochrome, expected: Color{}},
+		{color: Color{}, depth: ColorDepth16, expected: Color{}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.color.Downgrade(c.depth), "%v at depth %v", c.color, c.depth)
+	}
+}
+
+func TestDowngradeCommand(t *testing.T) {
+	bold := &SetGraphicsRendition{Command: SGRBold, Parameters: []int{}}
+	assert.Same(t, bold, DowngradeCommand(bold, ColorDepthMonochrome))
+
+	red := &SetGraphicsRendition{Command: SGRForegroundRed, Parameters: []int{}}
+	assert.Same(t, red, DowngradeCommand(red, ColorDepth16))
+	assert.Nil(t, DowngradeCommand(red, ColorDepthMonochrome))
+
+	rgb := BackgroundColor(RGBColor(255, 0, 0))
+	assert.Same(t, rgb, DowngradeCommand(rgb, ColorDepthTrueColor))
+	assert.Equal(t, BackgroundColor(IndexedColor(196)), DowngradeCommand(rgb, ColorDepth256))
+	assert.Equal(t, BackgroundColor(BrightColor(1)), DowngradeCommand(rgb, ColorDepth16))
+
+	list := &SGRList{*bold, *ForegroundColor(IndexedColor(196))}
+	assert.Same(t, list, DowngradeCommand(list, ColorDepth256))
+	assert.Equal(t, &SGRList{*bold, *ForegroundColor(BrightColor(1))}, DowngradeCommand(list, ColorDepth16))
+	assert.Equal(t, &SGRList{*bold}, DowngradeCommand(list, ColorDepthMonochrome))
+	assert.Nil(t, DowngradeCommand(&SGRList{*red}, ColorDepthMonochrome))
+
+	erase := &EraseInLine{}
+	assert.Same(t, erase, DowngradeCommand(erase, ColorDepthMonochrome))
+}
+
+func TestColorDepthWriter(t *testing.T) {
+	input := "\x1b[1;38;2;255;0;0mred\x1b[0m \x1b[38:2::0:0:255mblue\x1b[39m\x1b[2K \x1b[48;5;46mgreen"
+	cases := []struct {
+		depth    ColorDepth
+		expected string
+	}{
+		{depth: ColorDepthTrueColor, expected: input},
+		{depth: ColorDepth256, expected: "\x1b[1;38;5;196mred\x1b[0m \x1b[38;5;21mblue\x1b[39m\x1b[2K \x1b[48;5;46mgreen"},
+		{depth: ColorDepth16, expected: "\x1b[1;91mred\x1b[0m \x1b[34mblue\x1b[39m\x1b[2K \x1b[102mgreen"},
+		{depth: ColorDepthMonochrome, expected: "\x1b[1mred\x1b[0m blue\x1b[2K green"},
+	}
+	for _, c := range cases {
+		for split := 0; split <= len(input); split++ {
+			var b bytes.Buffer
+			w := NewColorDepthWriter(&b, c.depth)
+			_, err := w.Write([]byte(input[:split]))
+			assert.NoError(t, err)
+			_, err = w.Write([]byte(input[split:]))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Flush())
+			assert.Equal(t, c.expected, b.String(), "depth %v, split at %d", c.depth, split)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package ansicsi
 /*
 Package ansicsi decodes and encodes ANSI control sequences as defined in ECMA-48/ANSI X3.64.
 
-The high-level decoder currently only supports the Set Graphics Rendition control function. All other control
-functions are returned as a tuple of (parameter bytes, intermediate bytes, final byte).
+The high-level decoder currently supports the Set Graphics Rendition, cursor movement, tabulation, erase and edit,
+repeat, scrolling, device status report, device attributes, and ANSI and DEC private mode control functions.
+Sequences that select a single graphics rendition aspect are returned as a SetGraphicsRendition; sequences that
+select several aspects at once are returned as an SGRList. All other control functions are returned as a tuple of
+(parameter bytes, intermediate bytes, final byte).
 
 The decoder can be called in a loop in order to separate control sequences from normal text:
 
@@ -22,9 +25,56 @@ The decoder can be called in a loop in order to separate control sequences from
 		bytes = bytes[1:]
 	}
 
+Streams of input can be decoded using a Scanner, which reads from an io.Reader and returns runs of text and decoded
+control functions:
+
+	s := NewScanner(r)
+	for s.Scan() {
+		if cmd := s.Token().Command; cmd != nil {
+			// Handle control functions here
+		} else {
+			// Handle plain text here
+		}
+	}
+	if err := s.Err(); err != nil {
+		// Handle errors here
+	}
+
 A command can be encoded using its Encode method:
 
 	resetCommand := SetGraphicsRendition{Command: SGRReset}
 	sz, err := resetCommand.Encode(w)
 
+Decode only recognizes the 7-bit control sequence introducer ESC [. DecodeC1 additionally recognizes the 8-bit C1
+control sequence introducer (0x9b), and EncodeC1 encodes a command using the 8-bit form.
+
+NewStripWriter returns an io.Writer that removes control sequences from the text written to it before forwarding the
+text to another writer, optionally keeping selected control functions (e.g. KeepSGR). Escape sequences that do not
+begin with a control sequence introducer, such as ESC ( B, are forwarded unchanged.
+
+NewHTMLWriter returns an io.Writer that converts text and Set Graphics Rendition control functions to HTML using
+either inline styles or CSS classes (see HTMLOptions.Stylesheet).
+
+The graphics rendition selected by a sequence of Set Graphics Rendition control functions can be tracked using a
+Style value and its Apply method.
+
+EncodeStyleChange writes the shortest control sequence that changes the graphics rendition from one Style to another.
+
+Colors selected by SGR commands are represented by the Color type, which can be constructed with ANSIColor,
+BrightColor, IndexedColor, and RGBColor, converted to an SGR command with ForegroundColor, BackgroundColor, and
+UnderlineColor, and recovered from an SGR command with SetGraphicsRendition.Color.
+
+DowngradeCommand and NewColorDepthWriter rewrite SGR colors for terminals that support fewer colors (see ColorDepth).
+
+Width, Truncate, and PadRight measure and adjust the display width of text that contains control sequences. Control
+sequences occupy no columns and are preserved, and any graphics rendition left open by truncation is reset.
+
+Wrap wraps text that contains control sequences to a display width. Each line of its result ends with a reset and
+begins by reselecting the graphics rendition that was in effect, so lines render correctly on their own.
+
+Screen is an in-memory model of a terminal screen. Data written to a Screen moves its cursor and updates its cells,
+so tests can assert on the text and graphics rendition that a user would see.
+
+NewRepeatWriter replaces Repeat control functions with the characters that they repeat. Place it in front of a
+StripWriter to keep repeated characters in stripped output.
 */
@@ -0,0 +1,141 @@
+package ansicsi
+
+import "io"
+
+const (
+	EraseToEnd      = 0 // Erase from the active position to the end of the page, line, field, or area
+	EraseToStart    = 1 // Erase from the start of the page, line, field, or area to the active position
+	EraseAll        = 2 // Erase the entire page, line, field, or area
+	EraseScrollback = 3 // Erase the scrollback buffer (xterm extension, ED only)
+)
+
+// EraseInPage represents an ERASE IN PAGE (ED) control function.
+type EraseInPage struct {
+	// Mode selects the portion of the page to erase. Defaults to EraseToEnd.
+	Mode int
+}
+
+func (e *EraseInPage) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{e.Mode}, nil, 0x4a)
+}
+
+func (e *EraseInPage) decodeParameters(params []int) bool {
+	return decodePs(params, &e.Mode) && e.Mode <= EraseScrollback
+}
+
+// EraseInLine represents an ERASE IN LINE (EL) control function.
+type EraseInLine struct {
+	// Mode selects the portion of the line to erase. Defaults to EraseToEnd.
+	Mode int
+}
+
+func (e *EraseInLine) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{e.Mode}, nil, 0x4b)
+}
+
+func (e *EraseInLine) decodeParameters(params []int) bool {
+	return decodePs(params, &e.Mode) && e.Mode <= EraseAll
+}
+
+// EraseInField represents an ERASE IN FIELD (EF) control function.
+type EraseInField struct {
+	// Mode selects the portion of the field to erase. Defaults to EraseToEnd.
+	Mode int
+}
+
+func (e *EraseInField) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{e.Mode}, nil, 0x4e)
+}
+
+func (e *EraseInField) decodeParameters(params []int) bool {
+	return decodePs(params, &e.Mode) && e.Mode <= EraseAll
+}
+
+// EraseInArea represents an ERASE IN AREA (EA) control function.
+type EraseInArea struct {
+	// Mode selects the portion of the qualified area to erase. Defaults to EraseToEnd.
+	Mode int
+}
+
+func (e *EraseInArea) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{e.Mode}, nil, 0x4f)
+}
+
+func (e *EraseInArea) decodeParameters(params []int) bool {
+	return decodePs(params, &e.Mode) && e.Mode <= EraseAll
+}
+
+// EraseCharacter represents an ERASE CHARACTER (ECH) control function, which erases the given number of characters
+// beginning at the active position.
+type EraseCharacter struct {
+	// N is the number of characters to erase. Defaults to 1.
+	N int
+}
+
+func (e *EraseCharacter) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{e.N}, nil, 0x58)
+}
+
+func (e *EraseCharacter) decodeParameters(params []int) bool {
+	return decodePn(params, &e.N)
+}
+
+// InsertCharacter represents an INSERT CHARACTER (ICH) control function, which inserts the given number of blank
+// characters at the active position, shifting the rest of the line forward.
+type InsertCharacter struct {
+	// N is the number of characters to insert. Defaults to 1.
+	N int
+}
+
+func (i *InsertCharacter) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{i.N}, nil, 0x40)
+}
+
+func (i *InsertCharacter) decodeParameters(params []int) bool {
+	return decodePn(params, &i.N)
+}
+
+// DeleteCharacter represents a DELETE CHARACTER (DCH) control function, which deletes the given number of characters
+// beginning at the active position, shifting the rest of the line backward.
+type DeleteCharacter struct {
+	// N is the number of characters to delete. Defaults to 1.
+	N int
+}
+
+func (d *DeleteCharacter) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{d.N}, nil, 0x50)
+}
+
+func (d *DeleteCharacter) decodeParameters(params []int) bool {
+	return decodePn(params, &d.N)
+}
+
+// InsertLine represents an INSERT LINE (IL) control function, which inserts the given number of blank lines at the
+// active line, shifting the following lines down.
+type InsertLine struct {
+	// N is the number of lines to insert. Defaults to 1.
+	N int
+}
+
+func (i *InsertLine) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{i.N}, nil, 0x4c)
+}
+
+func (i *InsertLine) decodeParameters(params []int) bool {
+	return decodePn(params, &i.N)
+}
+
+// DeleteLine represents a DELETE LINE (DL) control function, which deletes the given number of lines beginning at the
+// active line, shifting the following lines up.
+type DeleteLine struct {
+	// N is the number of lines to delete. Defaults to 1.
+	N int
+}
+
+func (d *DeleteLine) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{d.N}, nil, 0x4d)
+}
+
+func (d *DeleteLine) decodeParameters(params []int) bool {
+	return decodePn(params, &d.N)
+}
@@ -0,0 +1,28 @@
+package ansicsi
+
+import "testing"
+
+func TestEdit(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[J", cmd: &EraseInPage{Mode: EraseToEnd}, encoded: "\x1b[0J"},
+		{command: "\x1b[1J", cmd: &EraseInPage{Mode: EraseToStart}},
+		{command: "\x1b[2J", cmd: &EraseInPage{Mode: EraseAll}},
+		{command: "\x1b[3J", cmd: &EraseInPage{Mode: EraseScrollback}},
+		{command: "\x1b[K", cmd: &EraseInLine{Mode: EraseToEnd}, encoded: "\x1b[0K"},
+		{command: "\x1b[2K", cmd: &EraseInLine{Mode: EraseAll}},
+		{command: "\x1b[1N", cmd: &EraseInField{Mode: EraseToStart}},
+		{command: "\x1b[2O", cmd: &EraseInArea{Mode: EraseAll}},
+		{command: "\x1b[8X", cmd: &EraseCharacter{N: 8}},
+		{command: "\x1b[X", cmd: &EraseCharacter{N: 1}, encoded: "\x1b[1X"},
+		{command: "\x1b[2@", cmd: &InsertCharacter{N: 2}},
+		{command: "\x1b[3P", cmd: &DeleteCharacter{N: 3}},
+		{command: "\x1b[0P", cmd: &DeleteCharacter{N: 1}, encoded: "\x1b[1P"},
+		{command: "\x1b[4L", cmd: &InsertLine{N: 4}},
+		{command: "\x1b[5M", cmd: &DeleteLine{N: 5}},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestEdit_InvalidMode(t *testing.T) {
+	assertUnrecognized(t, "\x1b[4J", "\x1b[3K", "\x1b[1;2K")
+}
@@ -0,0 +1,260 @@
+package ansicsi
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLOptions configures the conversion of text and Set Graphics Rendition control functions to HTML.
+type HTMLOptions struct {
+	// Classes selects class-based output. If Classes is true, graphics renditions are emitted as class attributes
+	// that refer to the rules returned by Stylesheet, except for 24-bit colors, which are always emitted as inline
+	// styles. If Classes is false, graphics renditions are emitted as inline styles.
+	Classes bool
+	// ClassPrefix is the prefix for class names. Defaults to "ansi-".
+	ClassPrefix string
+	// DefaultForeground is the CSS color of the default foreground. It is used to render inverse text. Defaults to
+	// "black".
+	DefaultForeground string
+	// DefaultBackground is the CSS color of the default background. It is used to render inverse text. Defaults to
+	// "white".
+	DefaultBackground string
+}
+
+func (o HTMLOptions) classPrefix() string {
+	if o.ClassPrefix == "" {
+		return "ansi-"
+	}
+	return o.ClassPrefix
+}
+
+func (o HTMLOptions) defaultForeground() string {
+	if o.DefaultForeground == "" {
+		return "black"
+	}
+	return o.DefaultForeground
+}
+
+func (o HTMLOptions) defaultBackground() string {
+	if o.DefaultBackground == "" {
+		return "white"
+	}
+	return o.DefaultBackground
+}
+
+// Indices into htmlAttributes.
+const (
+	htmlBold = iota
+	htmlFaint
+	htmlItalic
+	htmlUnderline     // followed by the double, curly, dotted, and dashed underline styles
+	htmlStrikethrough = htmlUnderline + UnderlineDashed
+	htmlConceal       = htmlStrikethrough + 1
+)
+
+// htmlAttributes maps class name suffixes for the graphics rendition attributes to their CSS declarations.
+var htmlAttributes = []struct {
+	class, style string
+}{
+	{"bold", "font-weight:bold"},
+	{"faint", "opacity:0.5"},
+	{"italic", "font-style:italic"},
+	{"underline", "text-decoration:underline"},
+	{"double-underline", "text-decoration:underline double"},
+	{"curly-underline", "text-decoration:underline wavy"},
+	{"dotted-underline", "text-decoration:underline dotted"},
+	{"dashed-underline", "text-decoration:underline dashed"},
+	{"strike", "text-decoration:line-through"},
+	{"conceal", "visibility:hidden"},
+}
+
+// Stylesheet returns the CSS rules for the class names emitted by class-based output.
+func (o HTMLOptions) Stylesheet() string {
+	prefix := o.classPrefix()
+
+	var b strings.Builder
+	for _, a := range htmlAttributes {
+		fmt.Fprintf(&b, ".%s%s { %s; }\n", prefix, a.class, a.style)
+	}
+	fmt.Fprintf(&b, ".%sfg-inverse { color: %s; }\n", prefix, o.defaultBackground())
+	fmt.Fprintf(&b, ".%sbg-inverse { background-color: %s; }\n", prefix, o.defaultForeground())
+	for i := 0; i < 256; i++ {
+		r, g, bl := paletteRGB(i)
+		fmt.Fprintf(&b, ".%sfg-%d { color: #%02x%02x%02x; }\n", prefix, i, r, g, bl)
+	}
+	for i := 0; i < 256; i++ {
+		r, g, bl := paletteRGB(i)
+		fmt.Fprintf(&b, ".%sbg-%d { background-color: #%02x%02x%02x; }\n", prefix, i, r, g, bl)
+	}
+	return b.String()
+}
+
+// HTML converts text that contains control sequences to HTML using the given options. See HTMLWriter for details.
+func HTML(b []byte, options HTMLOptions) string {
+	var buf bytes.Buffer
+	w := NewHTMLWriter(&buf, options)
+	w.Write(b)
+	w.Close()
+	return buf.String()
+}
+
+// HTMLWriter is an io.Writer that converts text that contains control sequences to HTML. Text is HTML-escaped, and
+// the graphics rendition selected by Set Graphics Rendition control functions is rendered using nested <span>
+// elements, one per attribute. All other control functions are removed.
+//
+// Close must be called once all text has been written in order to close any open <span> elements.
+type HTMLWriter struct {
+	w        io.Writer
+	options  HTMLOptions
+	splitter sequenceSplitter
+	style    Style
+	open     []htmlSpan
+}
+
+// NewHTMLWriter returns a new HTMLWriter that writes HTML to w.
+func NewHTMLWriter(w io.Writer, options HTMLOptions) *HTMLWriter {
+	return &HTMLWriter{w: w, options: options}
+}
+
+// Write converts the text and control sequences in b to HTML and writes the result to the underlying writer.
+func (h *HTMLWriter) Write(b []byte) (int, error) {
+	return h.splitter.split(b, h)
+}
+
+// Close writes any buffered incomplete control sequence as text and closes any open <span> elements. It does not
+// close the underlying writer.
+func (h *HTMLWriter) Close() error {
+	if err := h.splitter.flush(h); err != nil {
+		return err
+	}
+	return h.reconcile(nil)
+}
+
+func (h *HTMLWriter) handleText(text []byte) error {
+	if err := h.reconcile(h.spans()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(h.w, html.EscapeString(string(text)))
+	return err
+}
+
+func (h *HTMLWriter) handleCommand(cmd Command, raw []byte) error {
+	h.style.ApplyCommand(cmd)
+	return nil
+}
+
+// reconcile closes and opens <span> elements so that the open elements match the given list.
+func (h *HTMLWriter) reconcile(spans []htmlSpan) error {
+	common := 0
+	for common < len(h.open) && common < len(spans) && h.open[common] == spans[common] {
+		common++
+	}
+
+	var b strings.Builder
+	for i := len(h.open); i > common; i-- {
+		b.WriteString("</span>")
+	}
+	for _, s := range spans[common:] {
+		b.WriteString("<span")
+		if s.class != "" {
+			fmt.Fprintf(&b, ` class="%s"`, html.EscapeString(s.class))
+		}
+		if s.style != "" {
+			fmt.Fprintf(&b, ` style="%s"`, html.EscapeString(s.style))
+		}
+		b.WriteString(">")
+	}
+	h.open = append(h.open[:common], spans[common:]...)
+
+	if b.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// htmlSpan describes a single <span> element.
+type htmlSpan struct {
+	class string
+	style string
+}
+
+// spans returns the list of <span> elements that render the current graphics rendition.
+func (h *HTMLWriter) spans() []htmlSpan {
+	s := &h.style
+	prefix := h.options.classPrefix()
+
+	var spans []htmlSpan
+	attribute := func(index int) {
+		a := htmlAttributes[index]
+		if h.options.Classes {
+			spans = append(spans, htmlSpan{class: prefix + a.class})
+		} else {
+			spans = append(spans, htmlSpan{style: a.style})
+		}
+	}
+
+	fg, bg := s.Foreground, s.Background
+	if s.Inverse {
+		fg, bg = bg, fg
+	}
+	if span, ok := h.colorSpan(fg, "fg", "color", s.Inverse, h.options.defaultBackground()); ok {
+		spans = append(spans, span)
+	}
+	if span, ok := h.colorSpan(bg, "bg", "background-color", s.Inverse, h.options.defaultForeground()); ok {
+		spans = append(spans, span)
+	}
+
+	if s.Bold {
+		attribute(htmlBold)
+	}
+	if s.Faint {
+		attribute(htmlFaint)
+	}
+	if s.Italic {
+		attribute(htmlItalic)
+	}
+	if s.Underline != UnderlineNone {
+		attribute(htmlUnderline + s.Underline - UnderlineSingle)
+		if !s.UnderlineColor.IsDefault() {
+			span := &spans[len(spans)-1]
+			if span.style != "" {
+				span.style += ";"
+			}
+			span.style += "text-decoration-color:" + s.UnderlineColor.css()
+		}
+	}
+	if s.Strikethrough {
+		attribute(htmlStrikethrough)
+	}
+	if s.Conceal {
+		attribute(htmlConceal)
+	}
+	return spans
+}
+
+// colorSpan returns the <span> element that renders the given color, if any.
+func (h *HTMLWriter) colorSpan(c Color, kind, property string, inverse bool, inverseDefault string) (htmlSpan, bool) {
+	switch {
+	case c.IsDefault() && !inverse:
+		return htmlSpan{}, false
+	case c.IsDefault():
+		if h.options.Classes {
+			return htmlSpan{class: h.options.classPrefix() + kind + "-inverse"}, true
+		}
+		return htmlSpan{style: property + ":" + inverseDefault}, true
+	case c.Kind() != ColorRGB && h.options.Classes:
+		return htmlSpan{class: fmt.Sprintf("%s%s-%d", h.options.classPrefix(), kind, c.Index())}, true
+	default:
+		return htmlSpan{style: property + ":" + c.css()}, true
+	}
+}
+
+// css returns the CSS representation of the color.
+func (c Color) css() string {
+	r, g, b := c.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
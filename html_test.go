@@ -0,0 +1,85 @@
+package ansicsi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML_Inline(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "plain <text> & more", expected: "plain &lt;text&gt; &amp; more"},
+		{input: "\x1b[1mbold\x1b[0m", expected: `<span style="font-weight:bold">bold</span>`},
+		{
+			input:    "\x1b[31mred \x1b[1mbold\x1b[22m red\x1b[m",
+			expected: `<span style="color:#cd0000">red <span style="font-weight:bold">bold</span> red</span>`,
+		},
+		{
+			input: "\x1b[3;4:3;58;5;196mx\x1b[9my\x1b[24mz\x1b[0m",
+			expected: `<span style="font-style:italic"><span style="text-decoration:underline wavy;text-decoration-color:#ff0000">` +
+				`x<span style="text-decoration:line-through">y</span></span>` +
+				`<span style="text-decoration:line-through">z</span></span>`,
+		},
+		{
+			input:    "\x1b[38;2;1;2;3;48;5;17mx",
+			expected: `<span style="color:#010203"><span style="background-color:#00005f">x</span></span>`,
+		},
+		{
+			input: "\x1b[7mx\x1b[32my",
+			expected: `<span style="color:white"><span style="background-color:black">x</span>` +
+				`<span style="background-color:#00cd00">y</span></span>`,
+		},
+		{input: "\x1b[92mbright\x1b[39m", expected: `<span style="color:#00ff00">bright</span>`},
+		{input: "\x1b[8msecret\x1b[28m", expected: `<span style="visibility:hidden">secret</span>`},
+		{input: "\x1b[2Jcleared\x1b[1m", expected: "cleared"},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			assert.Equal(t, c.expected, HTML([]byte(c.input), HTMLOptions{}))
+		})
+	}
+}
+
+func TestHTML_Classes(t *testing.T) {
+	options := HTMLOptions{Classes: true}
+	assert.Equal(t,
+		`<span class="ansi-fg-1"><span class="ansi-bold">x</span></span><span class="ansi-fg-200">y</span>`+
+			`<span style="color:#0a141e">z</span>`,
+		HTML([]byte("\x1b[1;31mx\x1b[22;38;5;200my\x1b[38;2;10;20;30mz"), options))
+	assert.Equal(t,
+		`<span class="ansi-fg-inverse"><span class="ansi-bg-inverse">x</span></span>`,
+		HTML([]byte("\x1b[7mx"), options))
+
+	options.ClassPrefix = "term-"
+	assert.Equal(t, `<span class="term-double-underline">x</span>`, HTML([]byte("\x1b[21mx"), options))
+
+	stylesheet := options.Stylesheet()
+	assert.Contains(t, stylesheet, ".term-bold { font-weight:bold; }\n")
+	assert.Contains(t, stylesheet, ".term-fg-1 { color: #cd0000; }\n")
+	assert.Contains(t, stylesheet, ".term-bg-255 { background-color: #eeeeee; }\n")
+	assert.Contains(t, stylesheet, ".term-fg-inverse { color: white; }\n")
+}
+
+func TestHTMLWriter_Split(t *testing.T) {
+	input := "\x1b[1;38;5;33mHello\x1b[0m, <world>"
+	expected := HTML([]byte(input), HTMLOptions{})
+
+	for split := 0; split <= len(input); split++ {
+		var b bytes.Buffer
+		w := NewHTMLWriter(&b, HTMLOptions{})
+		_, err := w.Write([]byte(input[:split]))
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(input[split:]))
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+
+		// Splitting the input may split text runs, but must not change the markup.
+		assert.Equal(t, expected, b.String(), "split at %d", split)
+	}
+	assert.True(t, strings.HasSuffix(expected, "</span>, &lt;world&gt;"))
+}
@@ -0,0 +1,124 @@
+package ansicsi
+
+import "io"
+
+const (
+	PrivateModeApplicationCursorKeys     = 1    // Cursor keys send application sequences (DECCKM)
+	PrivateModeReverseVideo              = 5    // Reverse video (DECSCNM)
+	PrivateModeOrigin                    = 6    // Cursor addressing is relative to the scrolling region (DECOM)
+	PrivateModeAutoWrap                  = 7    // Auto-wrap at the right margin (DECAWM)
+	PrivateModeMouseX10                  = 9    // Report mouse button presses (X10 compatibility)
+	PrivateModeCursorBlink               = 12   // Blinking cursor
+	PrivateModeCursorVisible             = 25   // Show the cursor (DECTCEM)
+	PrivateModeAlternateScreen           = 47   // Use the alternate screen buffer
+	PrivateModeMouseNormal               = 1000 // Report mouse button presses and releases
+	PrivateModeMouseHighlight            = 1001 // Report mouse highlight tracking
+	PrivateModeMouseButtonEvent          = 1002 // Report mouse motion while a button is pressed
+	PrivateModeMouseAnyEvent             = 1003 // Report all mouse motion
+	PrivateModeFocusEvents               = 1004 // Report focus in and focus out events
+	PrivateModeMouseUTF8                 = 1005 // Encode mouse reports as UTF-8
+	PrivateModeMouseSGR                  = 1006 // Encode mouse reports as SGR-style control sequences
+	PrivateModeMouseURXVT                = 1015 // Encode mouse reports as urxvt-style control sequences
+	PrivateModeMouseSGRPixels            = 1016 // Encode mouse reports as SGR-style control sequences with pixel coordinates
+	PrivateModeAlternateScreenClear      = 1047 // Use the alternate screen buffer, clearing it when leaving
+	PrivateModeSaveCursor                = 1048 // Save the cursor on set and restore it on reset
+	PrivateModeAlternateScreenSaveCursor = 1049 // Save the cursor and use a cleared alternate screen buffer
+	PrivateModeBracketedPaste            = 2004 // Bracket pasted text with ESC[200~ and ESC[201~
+	PrivateModeSynchronizedOutput        = 2026 // Defer rendering until the mode is reset
+)
+
+const (
+	ModeGuardedAreaTransfer         = 1  // GATM - GUARDED AREA TRANSFER MODE
+	ModeKeyboardAction              = 2  // KAM - KEYBOARD ACTION MODE; set to lock the keyboard
+	ModeControlRepresentation       = 3  // CRM - CONTROL REPRESENTATION MODE; set to display control functions
+	ModeInsertionReplacement        = 4  // IRM - INSERTION REPLACEMENT MODE; set to insert characters
+	ModeStatusReportTransfer        = 5  // SRTM - STATUS REPORT TRANSFER MODE
+	ModeErasure                     = 6  // ERM - ERASURE MODE
+	ModeLineEditing                 = 7  // VEM - LINE EDITING MODE
+	ModeBidirectionalSupport        = 8  // BDSM - BI-DIRECTIONAL SUPPORT MODE
+	ModeDeviceComponentSelect       = 9  // DCSM - DEVICE COMPONENT SELECT MODE
+	ModeCharacterEditing            = 10 // HEM - CHARACTER EDITING MODE
+	ModePositioningUnit             = 11 // PUM - POSITIONING UNIT MODE
+	ModeSendReceive                 = 12 // SRM - SEND/RECEIVE MODE; reset to echo keyboard input locally
+	ModeFormatEffectorAction        = 13 // FEAM - FORMAT EFFECTOR ACTION MODE
+	ModeFormatEffectorTransfer      = 14 // FETM - FORMAT EFFECTOR TRANSFER MODE
+	ModeMultipleAreaTransfer        = 15 // MATM - MULTIPLE AREA TRANSFER MODE
+	ModeTransferTermination         = 16 // TTM - TRANSFER TERMINATION MODE
+	ModeSelectedAreaTransfer        = 17 // SATM - SELECTED AREA TRANSFER MODE
+	ModeTabulationStop              = 18 // TSM - TABULATION STOP MODE
+	ModeLineFeedNewLine             = 20 // LNM - LINE FEED/NEW LINE MODE; set to make LF also return the carriage
+	ModeGraphicRenditionCombination = 21 // GRCM - GRAPHIC RENDITION COMBINATION MODE
+	ModeZeroDefault                 = 22 // ZDM - ZERO DEFAULT MODE
+)
+
+// SetMode represents a SET MODE (SM) control function, e.g. ESC[4h. SetMode sets ANSI modes only; DEC private modes
+// are set by SetPrivateMode.
+type SetMode struct {
+	// Modes lists the modes to set. Most are Mode* constants.
+	Modes []int
+}
+
+func (m *SetMode) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, m.Modes, nil, 0x68)
+}
+
+func (m *SetMode) decodeParameters(params []int) bool {
+	return decodeModes(params, &m.Modes)
+}
+
+// ResetMode represents a RESET MODE (RM) control function, e.g. ESC[4l. ResetMode resets ANSI modes only; DEC private
+// modes are reset by ResetPrivateMode.
+type ResetMode struct {
+	// Modes lists the modes to reset. Most are Mode* constants.
+	Modes []int
+}
+
+func (m *ResetMode) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, m.Modes, nil, 0x6c)
+}
+
+func (m *ResetMode) decodeParameters(params []int) bool {
+	return decodeModes(params, &m.Modes)
+}
+
+// SetPrivateMode represents a DEC PRIVATE MODE SET (DECSET) control function, e.g. ESC[?25h.
+type SetPrivateMode struct {
+	// Modes lists the private modes to set.
+	Modes []int
+}
+
+func (m *SetPrivateMode) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '?', m.Modes, nil, 0x68)
+}
+
+func (m *SetPrivateMode) decodeParameters(params []int) bool {
+	return decodeModes(params, &m.Modes)
+}
+
+// ResetPrivateMode represents a DEC PRIVATE MODE RESET (DECRST) control function, e.g. ESC[?25l.
+type ResetPrivateMode struct {
+	// Modes lists the private modes to reset.
+	Modes []int
+}
+
+func (m *ResetPrivateMode) Encode(w io.Writer) (int, error) {
+	return encodePrivateCommand(w, '?', m.Modes, nil, 0x6c)
+}
+
+func (m *ResetPrivateMode) decodeParameters(params []int) bool {
+	return decodeModes(params, &m.Modes)
+}
+
+// decodeModes decodes the parameters of a control function that takes a non-empty list of modes.
+func decodeModes(params []int, modes *[]int) bool {
+	if len(params) == 0 {
+		return false
+	}
+	for _, p := range params {
+		if p < 0 {
+			return false
+		}
+	}
+	*modes = params
+	return true
+}
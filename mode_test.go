@@ -0,0 +1,66 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivateMode(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[?25l", cmd: &ResetPrivateMode{Modes: []int{PrivateModeCursorVisible}}},
+		{command: "\x1b[?25h", cmd: &SetPrivateMode{Modes: []int{PrivateModeCursorVisible}}},
+		{command: "\x1b[?1049h", cmd: &SetPrivateMode{Modes: []int{PrivateModeAlternateScreenSaveCursor}}},
+		{command: "\x1b[?2004h", cmd: &SetPrivateMode{Modes: []int{PrivateModeBracketedPaste}}},
+		{command: "\x1b[?2026l", cmd: &ResetPrivateMode{Modes: []int{PrivateModeSynchronizedOutput}}},
+		{
+			command: "\x1b[?1000;1006;1004h",
+			cmd:     &SetPrivateMode{Modes: []int{PrivateModeMouseNormal, PrivateModeMouseSGR, PrivateModeFocusEvents}},
+		},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestPrivateMode_Unrecognized(t *testing.T) {
+	cases := []struct {
+		command string
+		private byte
+	}{
+		{command: "\x1b[?h", private: '?'},
+		{command: "\x1b[?1;h", private: '?'},
+		{command: "\x1b[>1m", private: '>'},
+		{command: "\x1b[=5u", private: '='},
+		{command: "\x1b[<0;10;20M", private: '<'},
+		{command: "\x1b[1?h", private: 0},
+	}
+	for _, c := range cases {
+		cmd, size := Decode([]byte(c.command))
+		cs, ok := cmd.(*ControlSequence)
+		if assert.True(t, ok, c.command) {
+			assert.Equal(t, len(c.command), size)
+			assert.Equal(t, c.private, cs.Private())
+		}
+	}
+}
+
+func TestMode(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[4h", cmd: &SetMode{Modes: []int{ModeInsertionReplacement}}},
+		{command: "\x1b[4l", cmd: &ResetMode{Modes: []int{ModeInsertionReplacement}}},
+		{command: "\x1b[20h", cmd: &SetMode{Modes: []int{ModeLineFeedNewLine}}},
+		{command: "\x1b[2;12l", cmd: &ResetMode{Modes: []int{ModeKeyboardAction, ModeSendReceive}}},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestMode_DistinctFromPrivateMode(t *testing.T) {
+	ansi, _ := Decode([]byte("\x1b[25h"))
+	assert.Equal(t, &SetMode{Modes: []int{25}}, ansi)
+
+	private, _ := Decode([]byte("\x1b[?25h"))
+	assert.Equal(t, &SetPrivateMode{Modes: []int{PrivateModeCursorVisible}}, private)
+}
+
+func TestMode_Invalid(t *testing.T) {
+	assertUnrecognized(t, "\x1b[h", "\x1b[l", "\x1b[4;h", "\x1b[;4l")
+}
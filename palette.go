@@ -0,0 +1,41 @@
+package ansicsi
+
+// ansiPalette holds the RGB values of the 16 ANSI colors as rendered by xterm. Indices 0-7 are the basic colors
+// selected by SGR 30-37 and 40-47; indices 8-15 are their bright variants.
+var ansiPalette = [16][3]uint8{
+	{0x00, 0x00, 0x00}, // black
+	{0xcd, 0x00, 0x00}, // red
+	{0x00, 0xcd, 0x00}, // green
+	{0xcd, 0xcd, 0x00}, // yellow
+	{0x00, 0x00, 0xee}, // blue
+	{0xcd, 0x00, 0xcd}, // magenta
+	{0x00, 0xcd, 0xcd}, // cyan
+	{0xe5, 0xe5, 0xe5}, // white
+	{0x7f, 0x7f, 0x7f}, // bright black
+	{0xff, 0x00, 0x00}, // bright red
+	{0x00, 0xff, 0x00}, // bright green
+	{0xff, 0xff, 0x00}, // bright yellow
+	{0x5c, 0x5c, 0xff}, // bright blue
+	{0xff, 0x00, 0xff}, // bright magenta
+	{0x00, 0xff, 0xff}, // bright cyan
+	{0xff, 0xff, 0xff}, // bright white
+}
+
+// cubeLevels holds the component values of the 6x6x6 color cube that occupies indices 16-231 of the xterm 256-color
+// palette.
+var cubeLevels = [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+
+// paletteRGB returns the RGB value of the given index in the xterm 256-color palette.
+func paletteRGB(index int) (r, g, b uint8) {
+	switch {
+	case index < 16:
+		c := ansiPalette[index]
+		return c[0], c[1], c[2]
+	case index < 232:
+		index -= 16
+		return cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6]
+	default:
+		gray := uint8(8 + 10*(index-232))
+		return gray, gray, gray
+	}
+}
@@ -0,0 +1,109 @@
+package ansicsi
+
+import (
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Repeat represents a REPEAT (REP) control function, which repeats the preceding graphic character the given number
+// of times.
+type Repeat struct {
+	// N is the number of times to repeat the character. Defaults to 1.
+	N int
+}
+
+func (r *Repeat) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{r.N}, nil, 0x62)
+}
+
+func (r *Repeat) decodeParameters(params []int) bool {
+	return decodePn(params, &r.N)
+}
+
+// MaxRepeat is the largest number of times that a RepeatWriter repeats a character for a single Repeat control
+// function. Larger counts are reduced to MaxRepeat so that a short control sequence cannot produce unbounded output.
+const MaxRepeat = 64 * 1024
+
+// RepeatWriter is an io.Writer that replaces Repeat control functions with the characters that they repeat before
+// forwarding its input to an underlying writer. Text and all other control sequences are forwarded unchanged, so a
+// RepeatWriter can be placed in front of a StripWriter to keep repeated characters in stripped output.
+//
+// A Repeat repeats the most recent graphic character written to the RepeatWriter, even if control functions were
+// written after it. A Repeat that is written before any graphic character is dropped. A Repeat repeats its character
+// at most MaxRepeat times.
+type RepeatWriter struct {
+	w        io.Writer
+	last     rune
+	partial  []byte
+	splitter sequenceSplitter
+}
+
+// NewRepeatWriter returns a RepeatWriter that writes to w.
+func NewRepeatWriter(w io.Writer) *RepeatWriter {
+	return &RepeatWriter{w: w, last: -1}
+}
+
+// Write writes b to the underlying writer, expanding Repeat control functions.
+func (r *RepeatWriter) Write(b []byte) (int, error) {
+	return r.splitter.split(b, r)
+}
+
+// Flush writes any buffered incomplete control sequence to the underlying writer.
+func (r *RepeatWriter) Flush() error {
+	return r.splitter.flush(r)
+}
+
+func (r *RepeatWriter) handleText(text []byte) error {
+	// Track the last graphic character, taking care to handle characters that are split across writes.
+	data := text
+	if len(r.partial) != 0 {
+		data = append(r.partial, text...)
+	}
+	r.partial = r.partial[:0]
+	for len(data) > 0 {
+		c, size := utf8.DecodeRune(data)
+		if c == utf8.RuneError && size == 1 {
+			if !utf8.FullRune(data) {
+				r.partial = append(r.partial, data...)
+				break
+			}
+		} else if unicode.IsGraphic(c) {
+			r.last = c
+		}
+		data = data[size:]
+	}
+
+	_, err := r.w.Write(text)
+	return err
+}
+
+func (r *RepeatWriter) handleCommand(cmd Command, raw []byte) error {
+	// A control sequence terminates any incomplete UTF-8 sequence.
+	r.partial = r.partial[:0]
+
+	rep, ok := cmd.(*Repeat)
+	if !ok {
+		_, err := r.w.Write(raw)
+		return err
+	}
+	if r.last < 0 {
+		return nil
+	}
+
+	// Write the repeated character in chunks of at most maxChunk characters.
+	const maxChunk = 1024
+
+	var encoded [utf8.UTFMax]byte
+	char := encoded[:utf8.EncodeRune(encoded[:], r.last)]
+	total := clamp(rep.N, 0, MaxRepeat)
+	chunk := bytes.Repeat(char, clamp(total, 0, maxChunk))
+	for n := total; n > 0; n -= maxChunk {
+		count := clamp(n, 0, maxChunk)
+		if _, err := r.w.Write(chunk[:count*len(char)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
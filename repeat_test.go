@@ -0,0 +1,79 @@
+package ansicsi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepeat(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[5b", cmd: &Repeat{N: 5}},
+		{command: "\x1b[b", cmd: &Repeat{N: 1}, encoded: "\x1b[1b"},
+		{command: "\x1b[0b", cmd: &Repeat{N: 1}, encoded: "\x1b[1b"},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestRepeatWriter(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "text", input: "hello", expected: "hello"},
+		{name: "repeat", input: "-\x1b[4b|", expected: "-----|"},
+		{name: "default", input: "ab\x1b[b", expected: "abb"},
+		{name: "wide", input: "世\x1b[2b", expected: "世世世"},
+		{name: "after sgr", input: "=\x1b[1m\x1b[3b", expected: "=\x1b[1m==="},
+		{name: "after control character", input: "x\r\n\x1b[2b", expected: "x\r\nxx"},
+		{name: "no preceding character", input: "\x1b[3bx", expected: "x"},
+		{name: "other sequences", input: "\x1b[2J\x1b[1;1H", expected: "\x1b[2J\x1b[1;1H"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for split := 0; split <= len(c.input); split++ {
+				var b bytes.Buffer
+				w := NewRepeatWriter(&b)
+				_, err := w.Write([]byte(c.input[:split]))
+				assert.NoError(t, err)
+				_, err = w.Write([]byte(c.input[split:]))
+				assert.NoError(t, err)
+				assert.NoError(t, w.Flush())
+				assert.Equal(t, c.expected, b.String(), "split at %d", split)
+			}
+		})
+	}
+}
+
+func TestRepeatWriter_Large(t *testing.T) {
+	var b bytes.Buffer
+	w := NewRepeatWriter(&b)
+	_, err := w.Write([]byte("\u00e9\x1b[2500b"))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("\u00e9", 2501), b.String())
+}
+
+func TestRepeatWriter_MaxRepeat(t *testing.T) {
+	var b bytes.Buffer
+	w := NewRepeatWriter(&b)
+	_, err := w.Write([]byte("a\x1b[2000000000bb"))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", MaxRepeat+1)+"b", b.String())
+}
+
+func TestRepeatWriter_Strip(t *testing.T) {
+	var b bytes.Buffer
+	w := NewRepeatWriter(NewStripWriter(&b, nil))
+	_, err := w.Write([]byte("\x1b[1m#\x1b[9b\x1b[0m done"))
+	assert.NoError(t, err)
+	assert.Equal(t, "########## done", b.String())
+}
+
+func TestRepeatWriter_Error(t *testing.T) {
+	w := NewRepeatWriter(errorWriter{})
+	_, err := w.Write([]byte("a\x1b[3b"))
+	assert.EqualError(t, err, "write failed")
+}
@@ -0,0 +1,48 @@
+package ansicsi
+
+import "io"
+
+const (
+	DSRReady                  = 0 // Ready, no malfunction detected
+	DSRBusy                   = 1 // Busy, another DSR must be requested later
+	DSRBusyReportLater        = 2 // Busy, another DSR will be sent later
+	DSRMalfunction            = 3 // Some malfunction detected, another DSR must be requested later
+	DSRMalfunctionReportLater = 4 // Some malfunction detected, another DSR will be sent later
+	DSRRequestStatus          = 5 // A DSR is requested
+	DSRRequestCursorPosition  = 6 // A report of the active position (CPR) is requested
+)
+
+// DeviceStatusReport represents a DEVICE STATUS REPORT (DSR) control function. A DSR either reports the status of
+// the device that sends it or requests a report from the device that receives it, e.g. ESC[6n requests a
+// CursorPositionReport.
+type DeviceStatusReport struct {
+	// Status is one of the DSR* constants. Defaults to DSRReady.
+	Status int
+}
+
+func (d *DeviceStatusReport) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{d.Status}, nil, 0x6e)
+}
+
+func (d *DeviceStatusReport) decodeParameters(params []int) bool {
+	return decodePs(params, &d.Status) && d.Status <= DSRRequestCursorPosition
+}
+
+// CursorPositionReport represents an ACTIVE POSITION REPORT (CPR) control function, which a terminal sends in reply
+// to a DeviceStatusReport with status DSRRequestCursorPosition.
+//
+// Note that some terminals send the same sequence for function keys with modifiers, e.g. ESC[1;2R for Shift+F3.
+type CursorPositionReport struct {
+	// Row is the 1-based line of the cursor. Defaults to 1.
+	Row int
+	// Column is the 1-based character position of the cursor. Defaults to 1.
+	Column int
+}
+
+func (c *CursorPositionReport) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.Row, c.Column}, nil, 0x52)
+}
+
+func (c *CursorPositionReport) decodeParameters(params []int) bool {
+	return decodePn(params, &c.Row, &c.Column)
+}
@@ -0,0 +1,38 @@
+package ansicsi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[6n", cmd: &DeviceStatusReport{Status: DSRRequestCursorPosition}},
+		{command: "\x1b[5n", cmd: &DeviceStatusReport{Status: DSRRequestStatus}},
+		{command: "\x1b[n", cmd: &DeviceStatusReport{Status: DSRReady}, encoded: "\x1b[0n"},
+		{command: "\x1b[3n", cmd: &DeviceStatusReport{Status: DSRMalfunction}},
+		{command: "\x1b[24;80R", cmd: &CursorPositionReport{Row: 24, Column: 80}},
+		{command: "\x1b[R", cmd: &CursorPositionReport{Row: 1, Column: 1}, encoded: "\x1b[1;1R"},
+		{command: "\x1b[5R", cmd: &CursorPositionReport{Row: 5, Column: 1}, encoded: "\x1b[5;1R"},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestReport_Invalid(t *testing.T) {
+	assertUnrecognized(t, "\x1b[7n", "\x1b[5;6n", "\x1b[1;2;3R")
+}
+
+func TestReport_Scanner(t *testing.T) {
+	s := NewScanner(bytes.NewReader([]byte("x\x1b[12;40Ry")))
+
+	var reports []*CursorPositionReport
+	for s.Scan() {
+		if cpr, ok := s.Token().Command.(*CursorPositionReport); ok {
+			reports = append(reports, cpr)
+		}
+	}
+	assert.NoError(t, s.Err())
+	assert.Equal(t, []*CursorPositionReport{{Row: 12, Column: 40}}, reports)
+}
@@ -0,0 +1,161 @@
+package ansicsi
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	// initialScanBufferSize is the initial size of a Scanner's buffer.
+	initialScanBufferSize = 4096
+	// MaxScanSequenceSize is the maximum size of a control sequence that can be decoded by a Scanner. Longer
+	// control sequences are returned as text.
+	MaxScanSequenceSize = 64 * 1024
+)
+
+// Token is a single token read by a Scanner: either a run of text or a control function.
+type Token struct {
+	// Bytes holds the raw bytes of the token.
+	Bytes []byte
+	// Command holds the decoded control function if the token is a control sequence, or nil if the token is text.
+	Command Command
+}
+
+// A Scanner reads text and control functions from an io.Reader. Successive calls to Scan step through the tokens in
+// the input. Control sequences that are split across reads are buffered until they are complete.
+//
+// Adjacent runs of text are not guaranteed to be returned as a single token: a long run of text may be returned as
+// several consecutive text tokens. Text tokens never split a UTF-8 encoded rune unless the input itself contains an
+// invalid encoding.
+type Scanner struct {
+	r     io.Reader
+	c1    bool
+	buf   []byte
+	start int
+	end   int
+	err   error
+	token Token
+}
+
+// NewScanner returns a new Scanner that reads from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{r: r}
+}
+
+// NewScannerC1 returns a new Scanner that reads from r and also recognizes the 8-bit C1 control sequence introducer
+// (0x9b). See DecodeC1 for details.
+func NewScannerC1(r io.Reader) *Scanner {
+	return &Scanner{r: r, c1: true}
+}
+
+// Scan advances the Scanner to the next token, which will then be available through the Token method. It returns
+// false when the scan stops, either by reaching the end of the input or an error. After Scan returns false, the Err
+// method will return any error that occurred during scanning, except that if it was io.EOF, Err will return nil.
+func (s *Scanner) Scan() bool {
+	for {
+		data := s.buf[s.start:s.end]
+		if len(data) > 0 {
+			full := s.err != nil || len(data) >= MaxScanSequenceSize
+			if size, cmd := s.next(data, full); size > 0 {
+				s.token = Token{Bytes: data[:size:size], Command: cmd}
+				s.start += size
+				return true
+			}
+		}
+		if s.err != nil {
+			s.token = Token{}
+			return false
+		}
+		s.fill()
+	}
+}
+
+// Token returns the most recent token read by a call to Scan. The underlying array of the token's Bytes may point to
+// data that will be overwritten by a subsequent call to Scan.
+func (s *Scanner) Token() Token {
+	return s.token
+}
+
+// Err returns the first non-EOF error that was encountered by the Scanner.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// next returns the size of the token at the start of data and its decoded command, if any. If the token may
+// continue past the end of data and more data may be available, next returns a size of 0.
+func (s *Scanner) next(data []byte, full bool) (int, Command) {
+	textStart := 0
+	if s.isIntroducer(data[0]) {
+		cmd, size, status := decode(data, s.c1)
+		switch {
+		case status == StatusOK:
+			return size, cmd
+		case status == StatusIncomplete && !full:
+			return 0, nil
+		}
+
+		// Not a control sequence: treat the introducer as text.
+		textStart = 1
+	}
+
+	for i := textStart; i < len(data); i++ {
+		if s.isIntroducer(data[i]) {
+			return i, nil
+		}
+	}
+	if full {
+		return len(data), nil
+	}
+
+	// Hold back an incomplete rune at the end of the data in case the rest of it has yet to be read.
+	size := len(data)
+	for i := 1; i < utf8.UTFMax && i <= size; i++ {
+		if utf8.RuneStart(data[size-i]) {
+			if !utf8.FullRune(data[size-i:]) {
+				size -= i
+			}
+			break
+		}
+	}
+	if size <= textStart {
+		return 0, nil
+	}
+	return size, nil
+}
+
+func (s *Scanner) isIntroducer(b byte) bool {
+	return b == 0x1b || s.c1 && b == 0x9b
+}
+
+// fill reads more data into the Scanner's buffer, growing the buffer if necessary.
+func (s *Scanner) fill() {
+	if s.start > 0 {
+		s.end = copy(s.buf, s.buf[s.start:s.end])
+		s.start = 0
+	}
+	if s.end == len(s.buf) {
+		size := 2 * len(s.buf)
+		if size == 0 {
+			size = initialScanBufferSize
+		}
+		buf := make([]byte, size)
+		copy(buf, s.buf[:s.end])
+		s.buf = buf
+	}
+
+	for attempts := 0; attempts < 100; attempts++ {
+		n, err := s.r.Read(s.buf[s.end:])
+		s.end += n
+		if err != nil {
+			s.err = err
+			return
+		}
+		if n > 0 {
+			return
+		}
+	}
+	s.err = io.ErrNoProgress
+}
@@ -0,0 +1,114 @@
+package ansicsi
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scanAll(t *testing.T, s *Scanner) []Token {
+	var tokens []Token
+	for s.Scan() {
+		tok := s.Token()
+		tokens = append(tokens, Token{Bytes: append([]byte(nil), tok.Bytes...), Command: tok.Command})
+	}
+	assert.NoError(t, s.Err())
+	return tokens
+}
+
+// mergeText merges adjacent text tokens.
+func mergeText(tokens []Token) []Token {
+	var merged []Token
+	for _, tok := range tokens {
+		if tok.Command == nil && len(merged) > 0 && merged[len(merged)-1].Command == nil {
+			merged[len(merged)-1].Bytes = append(merged[len(merged)-1].Bytes, tok.Bytes...)
+			continue
+		}
+		merged = append(merged, tok)
+	}
+	return merged
+}
+
+func TestScanner(t *testing.T) {
+	input := "\x1b[1;31mHello, \x1b[0mworld!\x1b[2J\x1b\x1bX\x1b[K"
+	expected := []Token{
+		{Bytes: []byte("\x1b[1;31m"), Command: &SGRList{
+			{Command: SGRBold, Parameters: []int{}},
+			{Command: SGRForegroundRed, Parameters: []int{}},
+		}},
+		{Bytes: []byte("Hello, ")},
+		{Bytes: []byte("\x1b[0m"), Command: &SetGraphicsRendition{Command: SGRReset, Parameters: []int{}}},
+		{Bytes: []byte("world!")},
+		{Bytes: []byte("\x1b[2J"), Command: &EraseInPage{Mode: EraseAll}},
+		{Bytes: []byte("\x1b\x1bX")},
+		{Bytes: []byte("\x1b[K"), Command: &EraseInLine{Mode: EraseToEnd}},
+	}
+
+	readers := map[string]io.Reader{
+		"whole":    strings.NewReader(input),
+		"byte":     iotest.OneByteReader(strings.NewReader(input)),
+		"half":     iotest.HalfReader(strings.NewReader(input)),
+		"data-err": iotest.DataErrReader(strings.NewReader(input)),
+	}
+	for name, r := range readers {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, mergeText(scanAll(t, NewScanner(r))))
+		})
+	}
+}
+
+func TestScanner_IncompleteAtEOF(t *testing.T) {
+	tokens := scanAll(t, NewScanner(iotest.OneByteReader(strings.NewReader("abc\x1b[12"))))
+	assert.Equal(t, []Token{{Bytes: []byte("abc\x1b[12")}}, mergeText(tokens))
+}
+
+func TestScanner_UTF8(t *testing.T) {
+	input := "héllo, 世界\x1b[1m🙂"
+	for _, tok := range scanAll(t, NewScanner(iotest.OneByteReader(strings.NewReader(input)))) {
+		if tok.Command == nil {
+			assert.True(t, utf8.Valid(tok.Bytes), string(tok.Bytes))
+		}
+	}
+}
+
+func TestScanner_C1(t *testing.T) {
+	tokens := scanAll(t, NewScannerC1(iotest.OneByteReader(strings.NewReader("a\x9b2Jb"))))
+	assert.Equal(t, []Token{
+		{Bytes: []byte("a")},
+		{Bytes: []byte("\x9b2J"), Command: &EraseInPage{Mode: EraseAll}},
+		{Bytes: []byte("b")},
+	}, tokens)
+}
+
+func TestScanner_Large(t *testing.T) {
+	var input bytes.Buffer
+	for i := 0; i < 10000; i++ {
+		input.WriteString("\x1b[32mline\x1b[0m\n")
+	}
+
+	var output bytes.Buffer
+	commands := 0
+	s := NewScanner(iotest.HalfReader(bytes.NewReader(input.Bytes())))
+	for s.Scan() {
+		if s.Token().Command != nil {
+			commands++
+		}
+		output.Write(s.Token().Bytes)
+	}
+	assert.NoError(t, s.Err())
+	assert.Equal(t, 20000, commands)
+	assert.Equal(t, input.Bytes(), output.Bytes())
+}
+
+func TestScanner_Error(t *testing.T) {
+	s := NewScanner(iotest.TimeoutReader(strings.NewReader("abc")))
+	assert.True(t, s.Scan())
+	assert.Equal(t, "abc", string(s.Token().Bytes))
+	assert.False(t, s.Scan())
+	assert.Equal(t, iotest.ErrTimeout, s.Err())
+}
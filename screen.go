@@ -0,0 +1,415 @@
+package ansicsi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Cell is a single character position on a Screen.
+type Cell struct {
+	// Rune is the character displayed in the cell. Blank cells hold a space. The cell that follows a character that
+	// occupies two columns holds 0.
+	Rune rune
+	// Style is the graphics rendition of the cell.
+	Style Style
+}
+
+// Screen is an in-memory model of a terminal screen. Data written to a Screen is interpreted the way a terminal would
+// interpret it: text is placed at the cursor, and the control characters CR, LF, VT, FF, BS, and HT and the cursor,
+// erase, insert, delete, scroll, tabulation, repeat, and Set Graphics Rendition control functions update the screen.
+// Other control characters and control functions are ignored, as are characters that occupy no columns.
+//
+// Text wraps to the next line when it reaches the right margin, and the scrolling region scrolls up when the cursor
+// moves down past its last line. The scrolling region is the entire screen unless it is restricted by
+// SetTopAndBottomMargins.
+type Screen struct {
+	width, height int
+
+	cells  [][]Cell
+	top    int // The first line of the scrolling region
+	bottom int // The line after the last line of the scrolling region
+	row    int
+	column int // May equal width if a wrap is pending
+	style  Style
+	tabs   *TabStops
+	last   rune // The last character placed on the screen, or -1
+
+	partial  []byte
+	splitter sequenceSplitter
+}
+
+// NewScreen returns a blank screen with the given number of columns and lines. The cursor is placed at the top-left
+// corner of the screen.
+func NewScreen(width, height int) *Screen {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	s := &Screen{
+		width:  width,
+		height: height,
+		cells:  make([][]Cell, height),
+		bottom: height,
+		tabs:   NewTabStops(width),
+		last:   -1,
+	}
+	for i := range s.cells {
+		s.cells[i] = make([]Cell, width)
+		s.clear(s.cells[i])
+	}
+	return s
+}
+
+// Size returns the number of columns and lines on the screen.
+func (s *Screen) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Cursor returns the 0-based line and character position of the cursor.
+func (s *Screen) Cursor() (row, column int) {
+	if s.column == s.width {
+		return s.row, s.width - 1
+	}
+	return s.row, s.column
+}
+
+// Style returns the graphics rendition that is applied to text written to the screen.
+func (s *Screen) Style() Style {
+	return s.style
+}
+
+// Cell returns the cell at the given 0-based line and character position.
+func (s *Screen) Cell(row, column int) Cell {
+	return s.cells[row][column]
+}
+
+// Row returns a copy of the cells on the given 0-based line.
+func (s *Screen) Row(row int) []Cell {
+	return append([]Cell(nil), s.cells[row]...)
+}
+
+// Line returns the text on the given 0-based line without trailing spaces.
+func (s *Screen) Line(row int) string {
+	var b strings.Builder
+	for _, c := range s.cells[row] {
+		if c.Rune != 0 {
+			b.WriteRune(c.Rune)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// String returns the text on the screen, with one line per screen line. Trailing spaces and trailing blank lines are
+// removed.
+func (s *Screen) String() string {
+	lines := make([]string, s.height)
+	for i := range lines {
+		lines[i] = s.Line(i)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Write interprets the data in b and updates the screen accordingly. Control sequences and UTF-8 sequences that are
+// split across calls to Write are buffered until they are complete.
+func (s *Screen) Write(b []byte) (int, error) {
+	return s.splitter.split(b, s)
+}
+
+// Apply updates the screen to reflect the given command. Commands that are not supported are ignored.
+func (s *Screen) Apply(cmd Command) {
+	switch cmd := cmd.(type) {
+	case *SetGraphicsRendition, *SGRList:
+		s.style.ApplyCommand(cmd)
+	case *CursorUp:
+		s.moveTo(s.up(cmd.N), s.column)
+	case *CursorDown:
+		s.moveTo(s.down(cmd.N), s.column)
+	case *CursorNextLine:
+		s.moveTo(s.down(cmd.N), 0)
+	case *CursorPrecedingLine:
+		s.moveTo(s.up(cmd.N), 0)
+	case *EraseInPage:
+		s.eraseInPage(cmd.Mode)
+	case *EraseInLine:
+		s.eraseInLine(cmd.Mode)
+	case *EraseCharacter:
+		row, column := s.Cursor()
+		s.clear(s.cells[row][column : column+clamp(cmd.N, 0, s.width-column)])
+	case *InsertCharacter:
+		s.insertCharacters(cmd.N)
+	case *DeleteCharacter:
+		s.deleteCharacters(cmd.N)
+	case *InsertLine:
+		if s.row >= s.top && s.row < s.bottom {
+			s.scrollDown(s.row, s.bottom, cmd.N)
+			s.column = 0
+		}
+	case *DeleteLine:
+		if s.row >= s.top && s.row < s.bottom {
+			s.scrollUp(s.row, s.bottom, cmd.N)
+			s.column = 0
+		}
+	case *ScrollUp:
+		s.scrollUp(s.top, s.bottom, cmd.N)
+	case *ScrollDown:
+		s.scrollDown(s.top, s.bottom, cmd.N)
+	case *ScrollLeft:
+		for _, line := range s.cells[s.top:s.bottom] {
+			s.deleteCells(line, cmd.N)
+		}
+	case *ScrollRight:
+		for _, line := range s.cells[s.top:s.bottom] {
+			s.insertCells(line, cmd.N)
+		}
+	case *SetTopAndBottomMargins:
+		s.setMargins(cmd.Top, cmd.Bottom)
+	case *Repeat:
+		if s.last >= 0 {
+			// Repeating the character more times than there are cells on the screen has no further visible effect.
+			n := clamp(cmd.N, 0, s.width*s.height)
+			for i := 0; i < n; i++ {
+				s.print(s.last, RuneWidth(s.last))
+			}
+		}
+	case *CursorForwardTabulation, *CursorBackwardTabulation, *TabulationClear, *CursorTabulationControl:
+		row, column := s.Cursor()
+		s.moveTo(row, s.tabs.Apply(cmd, column))
+	default:
+		row, column := s.Cursor()
+		if row, column, ok := ResolvePosition(cmd, row, column, s.width, s.height); ok {
+			s.moveTo(row, column)
+		}
+	}
+}
+
+func (s *Screen) handleText(text []byte) error {
+	if len(s.partial) != 0 {
+		text = append(s.partial, text...)
+		s.partial = s.partial[:0]
+	}
+
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(text) {
+			s.partial = append(s.partial, text...)
+			return nil
+		}
+		s.writeRune(r)
+		text = text[size:]
+	}
+	return nil
+}
+
+func (s *Screen) handleCommand(cmd Command, raw []byte) error {
+	// A control sequence terminates any incomplete UTF-8 sequence.
+	if len(s.partial) != 0 {
+		s.partial = s.partial[:0]
+		s.writeRune(utf8.RuneError)
+	}
+	s.Apply(cmd)
+	return nil
+}
+
+// writeRune interprets a single character.
+func (s *Screen) writeRune(r rune) {
+	switch r {
+	case '\r':
+		s.column = 0
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\b':
+		if s.column > 0 {
+			s.moveTo(s.row, s.column-1)
+		}
+	case '\t':
+		row, column := s.Cursor()
+		s.moveTo(row, s.tabs.Next(column, 1))
+	default:
+		if w := RuneWidth(r); w > 0 {
+			s.print(r, w)
+		}
+	}
+}
+
+// print places a character that occupies the given number of columns at the cursor and advances the cursor.
+func (s *Screen) print(r rune, width int) {
+	if width > s.width {
+		return
+	}
+	if s.column+width > s.width {
+		s.column = 0
+		s.lineFeed()
+	}
+
+	// Erase any wide characters that the new character partially overwrites.
+	line, start, end := s.cells[s.row], s.column, s.column+width
+	if line[start].Rune == 0 && start > 0 {
+		s.clear(line[start-1 : start])
+	}
+	if end < s.width && line[end].Rune == 0 {
+		s.clear(line[end : end+1])
+	}
+
+	s.last = r
+	line[start] = Cell{Rune: r, Style: s.style}
+	for i := start + 1; i < end; i++ {
+		line[i] = Cell{Rune: 0, Style: s.style}
+	}
+	s.column = end
+}
+
+// lineFeed moves the cursor down one line, scrolling the scrolling region up if the cursor is on its last line.
+func (s *Screen) lineFeed() {
+	switch {
+	case s.row == s.bottom-1:
+		s.scrollUp(s.top, s.bottom, 1)
+	case s.row < s.height-1:
+		s.row++
+	}
+}
+
+// up returns the line n lines above the cursor. The cursor does not move above the top of the scrolling region if it
+// starts at or below the top.
+func (s *Screen) up(n int) int {
+	n = clamp(n, -s.height, s.height)
+	if s.row >= s.top {
+		return clamp(s.row-n, s.top, s.row)
+	}
+	return s.row - n
+}
+
+// down returns the line n lines below the cursor. The cursor does not move below the bottom of the scrolling region if
+// it starts at or above the bottom.
+func (s *Screen) down(n int) int {
+	n = clamp(n, -s.height, s.height)
+	if s.row < s.bottom {
+		return clamp(s.row+n, s.row, s.bottom-1)
+	}
+	return s.row + n
+}
+
+// setMargins sets the scrolling region to the given 1-based lines and moves the cursor to the home position. A bottom
+// margin of 0 selects the last line of the screen. Invalid margins are ignored.
+func (s *Screen) setMargins(top, bottom int) {
+	if bottom == 0 || bottom > s.height {
+		bottom = s.height
+	}
+	if top < 1 || top >= bottom {
+		return
+	}
+	s.top, s.bottom = top-1, bottom
+	s.moveTo(0, 0)
+}
+
+// moveTo moves the cursor to the given 0-based position, clamped to the screen.
+func (s *Screen) moveTo(row, column int) {
+	s.row = clamp(row, 0, s.height-1)
+	s.column = clamp(column, 0, s.width-1)
+}
+
+// scrollUp moves lines [top, bottom) up by n lines. Lines that move above top are discarded and blank lines are
+// inserted at the bottom.
+func (s *Screen) scrollUp(top, bottom, n int) {
+	n = clamp(n, 0, bottom-top)
+	lines := s.cells[top:bottom]
+	discarded := append([][]Cell(nil), lines[:n]...)
+	copy(lines, lines[n:])
+	for i, line := range discarded {
+		s.clear(line)
+		lines[len(lines)-n+i] = line
+	}
+}
+
+// scrollDown moves lines [top, bottom) down by n lines. Lines that move below bottom are discarded and blank lines
+// are inserted at the top.
+func (s *Screen) scrollDown(top, bottom, n int) {
+	n = clamp(n, 0, bottom-top)
+	lines := s.cells[top:bottom]
+	discarded := append([][]Cell(nil), lines[len(lines)-n:]...)
+	copy(lines[n:], lines)
+	for i, line := range discarded {
+		s.clear(line)
+		lines[i] = line
+	}
+}
+
+func (s *Screen) eraseInPage(mode int) {
+	row, column := s.Cursor()
+	switch mode {
+	case EraseToEnd:
+		s.clear(s.cells[row][column:])
+		for _, line := range s.cells[row+1:] {
+			s.clear(line)
+		}
+	case EraseToStart:
+		for _, line := range s.cells[:row] {
+			s.clear(line)
+		}
+		s.clear(s.cells[row][:column+1])
+	case EraseAll:
+		for _, line := range s.cells {
+			s.clear(line)
+		}
+	}
+}
+
+func (s *Screen) eraseInLine(mode int) {
+	row, column := s.Cursor()
+	switch mode {
+	case EraseToEnd:
+		s.clear(s.cells[row][column:])
+	case EraseToStart:
+		s.clear(s.cells[row][:column+1])
+	case EraseAll:
+		s.clear(s.cells[row])
+	}
+}
+
+func (s *Screen) insertCharacters(n int) {
+	row, column := s.Cursor()
+	s.insertCells(s.cells[row][column:], n)
+}
+
+func (s *Screen) deleteCharacters(n int) {
+	row, column := s.Cursor()
+	s.deleteCells(s.cells[row][column:], n)
+}
+
+// insertCells moves the given cells right by n positions and blanks the first n cells.
+func (s *Screen) insertCells(cells []Cell, n int) {
+	n = clamp(n, 0, len(cells))
+	copy(cells[n:], cells)
+	s.clear(cells[:n])
+}
+
+// deleteCells moves the given cells left by n positions and blanks the last n cells.
+func (s *Screen) deleteCells(cells []Cell, n int) {
+	n = clamp(n, 0, len(cells))
+	copy(cells, cells[n:])
+	s.clear(cells[len(cells)-n:])
+}
+
+// clear blanks the given cells. Blank cells take the background color of the current graphics rendition.
+func (s *Screen) clear(cells []Cell) {
+	for i := range cells {
+		cells[i] = Cell{Rune: ' ', Style: Style{Background: s.style.Background}}
+	}
+}
+
+// clamp returns v limited to the range [lo, hi].
+func clamp(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	default:
+		return v
+	}
+}
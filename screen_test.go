@@ -0,0 +1,188 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		row, col int
+	}{
+		{name: "text", input: "hello", expected: "hello", row: 0, col: 5},
+		{name: "newline", input: "one\r\ntwo", expected: "one\ntwo", row: 1, col: 3},
+		{name: "line feed", input: "one\ntwo", expected: "one\n   two", row: 1, col: 6},
+		{name: "carriage return", input: "hello\rj", expected: "jello", row: 0, col: 1},
+		{name: "backspace", input: "ab\b\bc", expected: "cb", row: 0, col: 1},
+		{name: "tab", input: "a\tb", expected: "a       b", row: 0, col: 9},
+		{name: "wrap", input: "0123456789abc", expected: "0123456789\nabc", row: 1, col: 3},
+		{name: "pending wrap", input: "0123456789", expected: "0123456789", row: 0, col: 9},
+		{name: "pending wrap cancelled", input: "0123456789\rX", expected: "X123456789", row: 0, col: 1},
+		{name: "scroll", input: "1\r\n2\r\n3\r\n4\r\n5\r\n6", expected: "2\n3\n4\n5\n6", row: 4, col: 1},
+		{name: "wide", input: "世界", expected: "世界", row: 0, col: 4},
+		{name: "wide wrap", input: "012345678世", expected: "012345678\n世", row: 1, col: 2},
+		{name: "wide overwrite", input: "世界\x1b[2GX", expected: " X界", row: 0, col: 2},
+		{name: "combining", input: "e\u0301", expected: "e", row: 0, col: 1},
+		{name: "cursor up", input: "\r\n\r\nabc\x1b[2Ad", expected: "   d\n\nabc", row: 0, col: 4},
+		{name: "cursor down", input: "a\x1b[10Bb", expected: "a\n\n\n\n b", row: 4, col: 2},
+		{name: "cursor right", input: "a\x1b[3Cb", expected: "a   b", row: 0, col: 5},
+		{name: "cursor left", input: "abc\x1b[2Dd", expected: "adc", row: 0, col: 2},
+		{name: "cursor next line", input: "abc\x1b[Ed", expected: "abc\nd", row: 1, col: 1},
+		{name: "cursor preceding line", input: "\r\nabc\x1b[Fd", expected: "d\nabc", row: 0, col: 1},
+		{name: "cursor column", input: "abc\x1b[2Gd", expected: "adc", row: 0, col: 2},
+		{name: "cursor position", input: "\x1b[3;4Hx", expected: "\n\n   x", row: 2, col: 4},
+		{name: "cursor position clamped", input: "\x1b[99;99Hx", expected: "\n\n\n\n         x", row: 4, col: 9},
+		{name: "hvp", input: "\x1b[2;2fx", expected: "\n x", row: 1, col: 2},
+		{name: "erase to end of page", input: "abc\r\ndef\r\nghi\x1b[2;2H\x1b[J", expected: "abc\nd", row: 1, col: 1},
+		{name: "erase to start of page", input: "abc\r\ndef\r\nghi\x1b[2;2H\x1b[1J", expected: "\n  f\nghi", row: 1, col: 1},
+		{name: "erase page", input: "abc\r\ndef\x1b[2J", expected: "", row: 1, col: 3},
+		{name: "erase to end of line", input: "abcdef\x1b[3G\x1b[K", expected: "ab", row: 0, col: 2},
+		{name: "erase to start of line", input: "abcdef\x1b[3G\x1b[1K", expected: "   def", row: 0, col: 2},
+		{name: "erase line", input: "abcdef\x1b[2K", expected: "", row: 0, col: 6},
+		{name: "erase character", input: "abcdef\x1b[2G\x1b[2X", expected: "a  def", row: 0, col: 1},
+		{name: "insert character", input: "abcdef\x1b[2G\x1b[2@", expected: "a  bcdef", row: 0, col: 1},
+		{name: "insert character overflow", input: "0123456789\x1b[1G\x1b[3@", expected: "   0123456", row: 0, col: 0},
+		{name: "delete character", input: "abcdef\x1b[2G\x1b[2P", expected: "adef", row: 0, col: 1},
+		{name: "insert line", input: "1\r\n2\r\n3\x1b[2;2H\x1b[L", expected: "1\n\n2\n3", row: 1, col: 0},
+		{name: "insert lines", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[4H\x1b[3L", expected: "1\n2\n3", row: 3, col: 0},
+		{name: "delete line", input: "1\r\n2\r\n3\x1b[2H\x1b[M", expected: "1\n3", row: 1, col: 0},
+		{name: "scroll up", input: "1\r\n2\r\n3\x1b[2S", expected: "3", row: 2, col: 1},
+		{name: "scroll down", input: "1\r\n2\r\n3\x1b[T", expected: "\n1\n2\n3", row: 2, col: 1},
+		{name: "scroll left", input: "abc\r\ndef\x1b[2 @", expected: "c\nf", row: 1, col: 3},
+		{name: "scroll right", input: "abc\r\ndef\x1b[2 A", expected: "  abc\n  def", row: 1, col: 3},
+		{name: "margins home", input: "abc\x1b[2;4r", expected: "abc", row: 0, col: 0},
+		{name: "margins invalid", input: "abc\x1b[4;2r", expected: "abc", row: 0, col: 3},
+		{name: "margins line feed", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r\x1b[4H\nx", expected: "1\n3\n4\nx\n5", row: 3, col: 1},
+		{name: "margins line feed below", input: "1\x1b[2;3r\x1b[4H\n\n\nx", expected: "1\n\n\n\nx", row: 4, col: 1},
+		{name: "margins scroll up", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r\x1b[S", expected: "1\n3\n4\n\n5", row: 0, col: 0},
+		{name: "margins scroll down", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r\x1b[T", expected: "1\n\n2\n3\n5", row: 0, col: 0},
+		{name: "margins scroll left", input: "1a\r\n2b\r\n3c\x1b[2r\x1b[ @", expected: "1a\nb\nc", row: 0, col: 0},
+		{name: "margins insert line", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r\x1b[3H\x1b[L", expected: "1\n2\n\n3\n5", row: 2, col: 0},
+		{name: "margins delete line", input: "1\r\n2\r\n3\r\n4\r\n5\x1b[2;4r\x1b[3H\x1b[M", expected: "1\n2\n4\n\n5", row: 2, col: 0},
+		{name: "margins outside insert line", input: "1\r\n2\r\n3\x1b[2;3r\x1b[H\x1b[L", expected: "1\n2\n3", row: 0, col: 0},
+		{name: "margins cursor up", input: "\x1b[2;4r\x1b[3H\x1b[5Ax", expected: "\nx", row: 1, col: 1},
+		{name: "margins cursor down", input: "\x1b[2;4r\x1b[3H\x1b[5Bx", expected: "\n\n\nx", row: 3, col: 1},
+		{name: "margins cursor up below", input: "\x1b[2;3r\x1b[5H\x1b[4Ax", expected: "\nx", row: 1, col: 1},
+		{name: "margins cursor up above", input: "\x1b[3;4r\x1b[2H\x1b[4Ax", expected: "x", row: 0, col: 1},
+		{name: "margins reset", input: "1\x1b[2;3r\x1b[r\x1b[5H\nx", expected: "\n\n\n\nx", row: 4, col: 1},
+		{name: "tab at end", input: "0123456789\tx", expected: "012345678x", row: 0, col: 9},
+		{name: "forward tabulation", input: "\x1b[Ia\x1b[2Ib", expected: "        ab", row: 0, col: 9},
+		{name: "backward tabulation", input: "\x1b[9Ga\x1b[2Zb", expected: "b       a", row: 0, col: 1},
+		{name: "tab stop set", input: "\x1b[4G\x1b[W\x1b[G\tx", expected: "   x", row: 0, col: 4},
+		{name: "tab stop clear", input: "\x1b[9G\x1b[g\x1b[G\tx", expected: "         x", row: 0, col: 9},
+		{name: "tab stops clear all", input: "\x1b[3g\tx", expected: "         x", row: 0, col: 9},
+		{name: "hpa", input: "abc\x1b[2`d", expected: "adc", row: 0, col: 2},
+		{name: "hpr", input: "a\x1b[3ab", expected: "a   b", row: 0, col: 5},
+		{name: "hpb", input: "abc\x1b[2jd", expected: "adc", row: 0, col: 2},
+		{name: "vpa", input: "ab\x1b[3dc", expected: "ab\n\n  c", row: 2, col: 3},
+		{name: "vpr", input: "ab\x1b[2ec", expected: "ab\n\n  c", row: 2, col: 3},
+		{name: "vpb", input: "\r\n\r\nab\x1b[2kc", expected: "  c\n\nab", row: 0, col: 3},
+		{name: "vpr clamped", input: "a\x1b[99eb", expected: "a\n\n\n\n b", row: 4, col: 2},
+		{name: "repeat", input: "a\x1b[3bb", expected: "aaaab", row: 0, col: 5},
+		{name: "repeat wraps", input: "a\x1b[12b", expected: "aaaaaaaaaa\naaa", row: 1, col: 3},
+		{
+			name:     "repeat large count",
+			input:    "a\x1b[2000000000b",
+			expected: "aaaaaaaaaa\naaaaaaaaaa\naaaaaaaaaa\naaaaaaaaaa\na",
+			row:      4,
+			col:      1,
+		},
+		{name: "repeat without character", input: "\x1b[3bb", expected: "b", row: 0, col: 1},
+		{name: "ignored", input: "\x1b[?25la\x1b[6nb\a", expected: "ab", row: 0, col: 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := NewScreen(10, 5)
+			_, err := s.Write([]byte(c.input))
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, s.String())
+
+			row, col := s.Cursor()
+			assert.Equal(t, c.row, row)
+			assert.Equal(t, c.col, col)
+		})
+	}
+}
+
+func TestScreen_Cells(t *testing.T) {
+	s := NewScreen(10, 2)
+	_, err := s.Write([]byte("a\x1b[1;31mb\x1b[0;44mc\x1b[K"))
+	assert.NoError(t, err)
+
+	bold := Style{Bold: true, Foreground: ANSIColor(1)}
+	blue := Style{Background: ANSIColor(4)}
+	assert.Equal(t, Cell{Rune: 'a'}, s.Cell(0, 0))
+	assert.Equal(t, Cell{Rune: 'b', Style: bold}, s.Cell(0, 1))
+	assert.Equal(t, Cell{Rune: 'c', Style: blue}, s.Cell(0, 2))
+	assert.Equal(t, Cell{Rune: ' ', Style: blue}, s.Cell(0, 3))
+	assert.Equal(t, Cell{Rune: ' ', Style: blue}, s.Cell(0, 9))
+	assert.Equal(t, Cell{Rune: ' '}, s.Cell(1, 0))
+	assert.Equal(t, blue, s.Style())
+
+	row := s.Row(0)
+	assert.Len(t, row, 10)
+	row[0].Rune = 'x'
+	assert.Equal(t, 'a', s.Cell(0, 0).Rune)
+}
+
+func TestScreen_SplitWrites(t *testing.T) {
+	s := NewScreen(10, 2)
+	for _, b := range []byte("\x1b[1m世\x1b[2;3Hb") {
+		_, err := s.Write([]byte{b})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "世\n  b", s.String())
+	assert.Equal(t, Cell{Rune: '世', Style: Style{Bold: true}}, s.Cell(0, 0))
+	assert.Equal(t, Cell{Rune: 0, Style: Style{Bold: true}}, s.Cell(0, 1))
+}
+
+func TestScreen_Apply(t *testing.T) {
+	s := NewScreen(10, 2)
+	s.Apply(&CursorPosition{Row: 2, Column: 5})
+	row, col := s.Cursor()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 4, col)
+
+	width, height := s.Size()
+	assert.Equal(t, 10, width)
+	assert.Equal(t, 2, height)
+}
+
+func TestScreen_LargeCounts(t *testing.T) {
+	const maxInt = int(^uint(0) >> 1)
+	const minInt = -maxInt - 1
+
+	cases := []struct {
+		name     string
+		input    string
+		cmd      Command
+		expected string
+		row, col int
+	}{
+		{name: "erase character", input: "abcdef\x1b[3G", cmd: &EraseCharacter{N: maxInt}, expected: "ab", row: 0, col: 2},
+		{name: "cursor down", input: "\x1b[3;4H", cmd: &CursorDown{N: maxInt}, row: 4, col: 3},
+		{name: "cursor next line", input: "\x1b[3;4H", cmd: &CursorNextLine{N: maxInt}, row: 4, col: 0},
+		{name: "cursor down in region", input: "\x1b[2;4r\x1b[3;4H", cmd: &CursorDown{N: maxInt}, row: 3, col: 3},
+		{name: "cursor up", input: "\x1b[3;4H", cmd: &CursorUp{N: maxInt}, row: 0, col: 3},
+		{name: "cursor up negative", input: "\x1b[3;4H", cmd: &CursorUp{N: minInt}, row: 2, col: 3},
+		{name: "cursor preceding line", input: "\x1b[3;4H", cmd: &CursorPrecedingLine{N: minInt}, row: 2, col: 0},
+		{name: "cursor down negative", input: "\x1b[3;4H", cmd: &CursorDown{N: minInt}, row: 2, col: 3},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := NewScreen(10, 5)
+			_, err := s.Write([]byte(c.input))
+			assert.NoError(t, err)
+			s.Apply(c.cmd)
+			assert.Equal(t, c.expected, s.String())
+
+			row, col := s.Cursor()
+			assert.Equal(t, c.row, row)
+			assert.Equal(t, c.col, col)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ansicsi
+
+import "io"
+
+// ScrollUp represents a SCROLL UP (SU) control function, which moves the contents of the scrolling region up by the
+// given number of lines. Lines that move out of the region are lost, and blank lines are inserted at the bottom.
+type ScrollUp struct {
+	// N is the number of lines to scroll. Defaults to 1.
+	N int
+}
+
+func (s *ScrollUp) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{s.N}, nil, 0x53)
+}
+
+func (s *ScrollUp) decodeParameters(params []int) bool {
+	return decodePn(params, &s.N)
+}
+
+// ScrollDown represents a SCROLL DOWN (SD) control function, which moves the contents of the scrolling region down by
+// the given number of lines. Lines that move out of the region are lost, and blank lines are inserted at the top.
+type ScrollDown struct {
+	// N is the number of lines to scroll. Defaults to 1.
+	N int
+}
+
+func (s *ScrollDown) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{s.N}, nil, 0x54)
+}
+
+func (s *ScrollDown) decodeParameters(params []int) bool {
+	return decodePn(params, &s.N)
+}
+
+// ScrollLeft represents a SCROLL LEFT (SL) control function, which moves the contents of the scrolling region left by
+// the given number of character positions.
+type ScrollLeft struct {
+	// N is the number of character positions to scroll. Defaults to 1.
+	N int
+}
+
+func (s *ScrollLeft) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{s.N}, []byte{0x20}, 0x40)
+}
+
+func (s *ScrollLeft) decodeParameters(params []int) bool {
+	return decodePn(params, &s.N)
+}
+
+// ScrollRight represents a SCROLL RIGHT (SR) control function, which moves the contents of the scrolling region right
+// by the given number of character positions.
+type ScrollRight struct {
+	// N is the number of character positions to scroll. Defaults to 1.
+	N int
+}
+
+func (s *ScrollRight) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{s.N}, []byte{0x20}, 0x41)
+}
+
+func (s *ScrollRight) decodeParameters(params []int) bool {
+	return decodePn(params, &s.N)
+}
+
+// SetTopAndBottomMargins represents a DEC SET TOP AND BOTTOM MARGINS (DECSTBM) control function, which selects the
+// lines of the scrolling region and moves the cursor to the home position.
+type SetTopAndBottomMargins struct {
+	// Top is the 1-based first line of the scrolling region. Defaults to 1.
+	Top int
+	// Bottom is the 1-based last line of the scrolling region, or 0 for the last line of the page. Defaults to 0.
+	Bottom int
+}
+
+func (s *SetTopAndBottomMargins) Encode(w io.Writer) (int, error) {
+	if s.Bottom == 0 {
+		return encodeCommand(w, []int{s.Top}, nil, 0x72)
+	}
+	return encodeCommand(w, []int{s.Top, s.Bottom}, nil, 0x72)
+}
+
+func (s *SetTopAndBottomMargins) decodeParameters(params []int) bool {
+	if !decodePs(params, &s.Top, &s.Bottom) {
+		return false
+	}
+	if s.Top == 0 {
+		s.Top = 1
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package ansicsi
+
+import "testing"
+
+func TestScroll(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[3S", cmd: &ScrollUp{N: 3}},
+		{command: "\x1b[S", cmd: &ScrollUp{N: 1}, encoded: "\x1b[1S"},
+		{command: "\x1b[2T", cmd: &ScrollDown{N: 2}},
+		{command: "\x1b[0T", cmd: &ScrollDown{N: 1}, encoded: "\x1b[1T"},
+		{command: "\x1b[4 @", cmd: &ScrollLeft{N: 4}},
+		{command: "\x1b[ @", cmd: &ScrollLeft{N: 1}, encoded: "\x1b[1 @"},
+		{command: "\x1b[5 A", cmd: &ScrollRight{N: 5}},
+		{command: "\x1b[2;20r", cmd: &SetTopAndBottomMargins{Top: 2, Bottom: 20}},
+		{command: "\x1b[r", cmd: &SetTopAndBottomMargins{Top: 1}, encoded: "\x1b[1r"},
+		{command: "\x1b[;10r", cmd: &SetTopAndBottomMargins{Top: 1, Bottom: 10}, encoded: "\x1b[1;10r"},
+		{command: "\x1b[5r", cmd: &SetTopAndBottomMargins{Top: 5}},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestScroll_TooManyParameters(t *testing.T) {
+	assertUnrecognized(t, "\x1b[1;2S", "\x1b[1;2T", "\x1b[1;2 @", "\x1b[1;2;3r")
+}
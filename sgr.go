@@ -1,6 +1,9 @@
 package ansicsi
 
-import "io"
+import (
+	"bytes"
+	"io"
+)
 
 const (
 	SGRReset                   = 0  // Default rendition (implementation-defined), cancels the effect of any preceding occurrence of SGR
@@ -69,52 +72,246 @@ const (
 	SGRIdeogramReset           = 65 // Cancels the effect of the rendition aspects established by ideogram parameter values
 )
 
+// Bright colors are non-standard extensions that originated in aixterm.
+const (
+	SGRForegroundBrightBlack   = 90  // Bright black foreground color
+	SGRForegroundBrightRed     = 91  // Bright red foreground color
+	SGRForegroundBrightGreen   = 92  // Bright green foreground color
+	SGRForegroundBrightYellow  = 93  // Bright yellow foreground color
+	SGRForegroundBrightBlue    = 94  // Bright blue foreground color
+	SGRForegroundBrightMagenta = 95  // Bright magenta foreground color
+	SGRForegroundBrightCyan    = 96  // Bright cyan foreground color
+	SGRForegroundBrightWhite   = 97  // Bright white foreground color
+	SGRBackgroundBrightBlack   = 100 // Bright black background color
+	SGRBackgroundBrightRed     = 101 // Bright red background color
+	SGRBackgroundBrightGreen   = 102 // Bright green background color
+	SGRBackgroundBrightYellow  = 103 // Bright yellow background color
+	SGRBackgroundBrightBlue    = 104 // Bright blue background color
+	SGRBackgroundBrightMagenta = 105 // Bright magenta background color
+	SGRBackgroundBrightCyan    = 106 // Bright cyan background color
+	SGRBackgroundBrightWhite   = 107 // Bright white background color
+)
+
+const (
+	UnderlineNone   = 0 // Not underlined
+	UnderlineSingle = 1 // Singly underlined
+	UnderlineDouble = 2 // Doubly underlined
+	UnderlineCurly  = 3 // Curly underline (non-standard)
+	UnderlineDotted = 4 // Dotted underline (non-standard)
+	UnderlineDashed = 5 // Dashed underline (non-standard)
+)
+
 // SetGraphicsRendition represents a single Set Graphics Rendition control function.
 type SetGraphicsRendition struct {
 	// Command describes the graphics rendition aspect that this call affects.
 	Command int
 	// Parameters are the parameters (if any) to the command.
 	Parameters []int
+	// Colon is true if the parameters are colon-delimited sub-parameters as described in ITU T.416, e.g.
+	// ESC[38:2::255:0:0m or ESC[4:3m, rather than semicolon-delimited parameters.
+	Colon bool
 }
 
 func (sgr *SetGraphicsRendition) Encode(w io.Writer) (int, error) {
-	params := make([]int, 0, len(sgr.Parameters)+1)
-	params = append(params, sgr.Command)
-	params = append(params, sgr.Parameters...)
-	return encodeCommand(w, params, nil, 0x6d)
+	var params bytes.Buffer
+	sgr.writeParameters(&params)
+	cs := ControlSequence{Parameters: params.Bytes(), Final: 0x6d}
+	return cs.Encode(w)
+}
+
+func (sgr *SetGraphicsRendition) writeParameters(params *bytes.Buffer) {
+	separator := byte(';')
+	if sgr.Colon {
+		separator = ':'
+	}
+
+	writeParameter(params, sgr.Command)
+	for _, p := range sgr.Parameters {
+		params.WriteByte(separator)
+		writeParameter(params, p)
+	}
 }
 
 func (sgr *SetGraphicsRendition) decodeParameters(params []int) bool {
-	if len(params) < 1 {
-		return true
+	var list SGRList
+	if !list.decodeParameters(params) || len(list) > 1 {
+		return false
+	}
+	if len(list) == 1 {
+		*sgr = list[0]
+	}
+	return true
+}
+
+// UnderlineStyle returns the underline style selected by an SGRUnderline, SGRDoubleUnderline, or SGRNoUnderline
+// command. ok is false for all other commands.
+func (sgr *SetGraphicsRendition) UnderlineStyle() (style int, ok bool) {
+	switch sgr.Command {
+	case SGRUnderline:
+		if len(sgr.Parameters) == 1 {
+			return sgr.Parameters[0], true
+		}
+		return UnderlineSingle, true
+	case SGRDoubleUnderline:
+		return UnderlineDouble, true
+	case SGRNoUnderline:
+		return UnderlineNone, true
 	}
+	return 0, false
+}
+
+// ColorIndex returns the palette index selected by an SGRForegroundColor, SGRBackgroundColor, or SGRUnderlineColor
+// command that uses the indexed color format (5). ok is false for all other commands.
+func (sgr *SetGraphicsRendition) ColorIndex() (index int, ok bool) {
+	if !sgr.isExtendedColor(5) {
+		return 0, false
+	}
+	return nonNegative(sgr.Parameters[1]), true
+}
 
-	command, params := params[0], params[1:]
-	switch command {
+// RGB returns the color components selected by an SGRForegroundColor, SGRBackgroundColor, or SGRUnderlineColor
+// command that uses the direct color format (2). ok is false for all other commands.
+func (sgr *SetGraphicsRendition) RGB() (r, g, b int, ok bool) {
+	if !sgr.isExtendedColor(2) {
+		return 0, 0, 0, false
+	}
+	rgb := sgr.Parameters[len(sgr.Parameters)-3:]
+	return nonNegative(rgb[0]), nonNegative(rgb[1]), nonNegative(rgb[2]), true
+}
+
+// ColorSpace returns the color space identifier of a direct color encoded as colon-delimited sub-parameters, e.g.
+// the 1 in ESC[38:2:1:255:0:0m. ok is false if the command does not specify a color space identifier.
+func (sgr *SetGraphicsRendition) ColorSpace() (id int, ok bool) {
+	if !sgr.isExtendedColor(2) || len(sgr.Parameters) != 5 || sgr.Parameters[1] < 0 {
+		return 0, false
+	}
+	return sgr.Parameters[1], true
+}
+
+// isExtendedColor returns true if the command selects an extended color in the given format (2 or 5) and has the
+// number of parameters that the format requires.
+func (sgr *SetGraphicsRendition) isExtendedColor(format int) bool {
+	switch sgr.Command {
 	case SGRForegroundColor, SGRBackgroundColor, SGRUnderlineColor:
-		if len(params) < 1 {
+		if len(sgr.Parameters) == 0 || sgr.Parameters[0] != format {
 			return false
 		}
-
-		depth := params[0]
-		switch depth {
+		switch format {
 		case 2:
-			if len(params) != 4 {
+			// Both ITU T.416 (38:2:<color space>:r:g:b) and the common variant without the color space identifier
+			// (38:2:r:g:b) are accepted.
+			return len(sgr.Parameters) == 4 || len(sgr.Parameters) == 5
+		case 5:
+			return len(sgr.Parameters) == 2
+		}
+	}
+	return false
+}
+
+// validSubParameters returns true if the command's parameters are valid colon-delimited sub-parameters.
+func (sgr *SetGraphicsRendition) validSubParameters() bool {
+	switch sgr.Command {
+	case SGRForegroundColor, SGRBackgroundColor, SGRUnderlineColor:
+		return sgr.isExtendedColor(2) || sgr.isExtendedColor(5)
+	case SGRUnderline:
+		return len(sgr.Parameters) == 1 && sgr.Parameters[0] >= UnderlineNone && sgr.Parameters[0] <= UnderlineDashed
+	}
+	return false
+}
+
+// SGRList represents a Set Graphics Rendition control function that affects several graphics rendition aspects,
+// e.g. ESC[1;31;48;5;17m. The aspects are listed in the order in which they appear in the control sequence.
+type SGRList []SetGraphicsRendition
+
+func (l *SGRList) Encode(w io.Writer) (int, error) {
+	var params bytes.Buffer
+	for i := range *l {
+		if i > 0 {
+			params.WriteByte(';')
+		}
+		(*l)[i].writeParameters(&params)
+	}
+	cs := ControlSequence{Parameters: params.Bytes(), Final: 0x6d}
+	return cs.Encode(w)
+}
+
+func (l *SGRList) decodeParameters(params []int) bool {
+	subParams := make([][]int, len(params))
+	for i := range params {
+		subParams[i] = params[i : i+1]
+	}
+	return l.decodeSubParameters(subParams)
+}
+
+func (l *SGRList) decodeSubParameters(params [][]int) bool {
+	var list SGRList
+	for len(params) > 0 {
+		param := params[0]
+		command := param[0]
+		if command < 0 {
+			// An omitted parameter is equivalent to 0.
+			command = SGRReset
+		}
+
+		// A command with sub-parameters carries all of its arguments in a single parameter.
+		if len(param) > 1 {
+			sgr := SetGraphicsRendition{Command: command, Parameters: param[1:], Colon: true}
+			if !sgr.validSubParameters() {
 				return false
 			}
-		case 5:
-			if len(params) != 2 {
+			list, params = append(list, sgr), params[1:]
+			continue
+		}
+
+		argc := 0
+		switch command {
+		case SGRForegroundColor, SGRBackgroundColor, SGRUnderlineColor:
+			if len(params) < 2 {
+				return false
+			}
+
+			depth := params[1][0]
+			switch depth {
+			case 2:
+				argc = 4
+			case 5:
+				argc = 2
+			default:
+				return false
+			}
+			if len(params) < 1+argc {
 				return false
 			}
 		default:
-			return false
+			switch {
+			case command >= SGRReset && command <= SGRIdeogramReset:
+			case command >= SGRForegroundBrightBlack && command <= SGRForegroundBrightWhite:
+			case command >= SGRBackgroundBrightBlack && command <= SGRBackgroundBrightWhite:
+			default:
+				return false
+			}
 		}
-	default:
-		if command < 0 || command > 65 {
-			return false
+
+		args := make([]int, argc)
+		for i := range args {
+			arg := params[1+i]
+			if len(arg) != 1 {
+				return false
+			}
+			args[i] = arg[0]
 		}
+
+		list = append(list, SetGraphicsRendition{Command: command, Parameters: args})
+		params = params[1+argc:]
 	}
 
-	sgr.Command, sgr.Parameters = command, params
+	*l = list
 	return true
 }
+
+func nonNegative(i int) int {
+	if i < 0 {
+		return 0
+	}
+	return i
+}
@@ -1084,3 +1084,429 @@ func TestSGR_IdeogramReset(t *testing.T) {
 	assert.Equal(t, size, encodedSize)
 	assert.Equal(t, command, b.Bytes())
 }
+
+// bright black foreground color (aixterm)
+func TestSGR_ForegroundBrightBlack(t *testing.T) {
+	command := []byte("\x1b[90m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightBlack, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright red foreground color (aixterm)
+func TestSGR_ForegroundBrightRed(t *testing.T) {
+	command := []byte("\x1b[91m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightRed, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright green foreground color (aixterm)
+func TestSGR_ForegroundBrightGreen(t *testing.T) {
+	command := []byte("\x1b[92m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightGreen, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright yellow foreground color (aixterm)
+func TestSGR_ForegroundBrightYellow(t *testing.T) {
+	command := []byte("\x1b[93m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightYellow, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright blue foreground color (aixterm)
+func TestSGR_ForegroundBrightBlue(t *testing.T) {
+	command := []byte("\x1b[94m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightBlue, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright magenta foreground color (aixterm)
+func TestSGR_ForegroundBrightMagenta(t *testing.T) {
+	command := []byte("\x1b[95m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightMagenta, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright cyan foreground color (aixterm)
+func TestSGR_ForegroundBrightCyan(t *testing.T) {
+	command := []byte("\x1b[96m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightCyan, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright white foreground color (aixterm)
+func TestSGR_ForegroundBrightWhite(t *testing.T) {
+	command := []byte("\x1b[97m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 5, size)
+	assert.Equal(t, SGRForegroundBrightWhite, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright black background color (aixterm)
+func TestSGR_BackgroundBrightBlack(t *testing.T) {
+	command := []byte("\x1b[100m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightBlack, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright red background color (aixterm)
+func TestSGR_BackgroundBrightRed(t *testing.T) {
+	command := []byte("\x1b[101m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightRed, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright green background color (aixterm)
+func TestSGR_BackgroundBrightGreen(t *testing.T) {
+	command := []byte("\x1b[102m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightGreen, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright yellow background color (aixterm)
+func TestSGR_BackgroundBrightYellow(t *testing.T) {
+	command := []byte("\x1b[103m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightYellow, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright blue background color (aixterm)
+func TestSGR_BackgroundBrightBlue(t *testing.T) {
+	command := []byte("\x1b[104m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightBlue, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright magenta background color (aixterm)
+func TestSGR_BackgroundBrightMagenta(t *testing.T) {
+	command := []byte("\x1b[105m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightMagenta, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright cyan background color (aixterm)
+func TestSGR_BackgroundBrightCyan(t *testing.T) {
+	command := []byte("\x1b[106m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightCyan, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// bright white background color (aixterm)
+func TestSGR_BackgroundBrightWhite(t *testing.T) {
+	command := []byte("\x1b[107m")
+	cmd, size := Decode(command)
+	sgr, ok := cmd.(*SetGraphicsRendition)
+	assert.True(t, ok)
+	assert.Equal(t, 6, size)
+	assert.Equal(t, SGRBackgroundBrightWhite, sgr.Command)
+
+	var b bytes.Buffer
+	encodedSize, err := sgr.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// multiple graphics rendition aspects in a single control sequence
+func TestSGRList(t *testing.T) {
+	command := []byte("\x1b[1;31;48;5;17;58;2;32;64;128;4m")
+	cmd, size := Decode(command)
+	list, ok := cmd.(*SGRList)
+	assert.True(t, ok)
+	assert.Equal(t, len(command), size)
+	assert.Equal(t, SGRList{
+		{Command: SGRBold, Parameters: []int{}},
+		{Command: SGRForegroundRed, Parameters: []int{}},
+		{Command: SGRBackgroundColor, Parameters: []int{5, 17}},
+		{Command: SGRUnderlineColor, Parameters: []int{2, 32, 64, 128}},
+		{Command: SGRUnderline, Parameters: []int{}},
+	}, *list)
+
+	var b bytes.Buffer
+	encodedSize, err := list.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// omitted parameters in a compound control sequence are equivalent to 0
+func TestSGRList_OmittedParameters(t *testing.T) {
+	bold := SetGraphicsRendition{Command: SGRBold, Parameters: []int{}}
+	reset := SetGraphicsRendition{Command: SGRReset, Parameters: []int{}}
+
+	cases := []roundTripCase{
+		{command: "\x1b[1;m", cmd: &SGRList{bold, reset}, encoded: "\x1b[1;0m"},
+		{command: "\x1b[;1m", cmd: &SGRList{reset, bold}, encoded: "\x1b[0;1m"},
+		{command: "\x1b[;m", cmd: &SGRList{reset, reset}, encoded: "\x1b[0;0m"},
+	}
+	assertRoundTrip(t, cases)
+}
+
+// malformed extended colors in a compound control sequence
+func TestSGRList_Invalid(t *testing.T) {
+	assertUnrecognized(t, "\x1b[1;38;5m", "\x1b[31;38;2;1;2m", "\x1b[1;38;3;1m", "\x1b[1;66m")
+}
+
+// colon-delimited sub-parameters as described in ITU T.416
+func TestSGR_SubParameters(t *testing.T) {
+	cases := []struct {
+		command    string
+		parameters []int
+		r, g, b    int
+		colorSpace int
+		index      int
+		underline  int
+	}{
+		{
+			command: "\x1b[38:2::255:128:0m", parameters: []int{2, -1, 255, 128, 0},
+			r: 255, g: 128, b: 0, colorSpace: -1, index: -1, underline: -1,
+		},
+		{
+			command: "\x1b[48:2:1:10:20:30m", parameters: []int{2, 1, 10, 20, 30},
+			r: 10, g: 20, b: 30, colorSpace: 1, index: -1, underline: -1,
+		},
+		{
+			command: "\x1b[58:2:10:20:30m", parameters: []int{2, 10, 20, 30},
+			r: 10, g: 20, b: 30, colorSpace: -1, index: -1, underline: -1,
+		},
+		{
+			command: "\x1b[38:5:17m", parameters: []int{5, 17},
+			r: -1, colorSpace: -1, index: 17, underline: -1,
+		},
+		{
+			command: "\x1b[4:3m", parameters: []int{UnderlineCurly},
+			r: -1, colorSpace: -1, index: -1, underline: UnderlineCurly,
+		},
+		{
+			command: "\x1b[4:0m", parameters: []int{UnderlineNone},
+			r: -1, colorSpace: -1, index: -1, underline: UnderlineNone,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.command[1:], func(t *testing.T) {
+			cmd, size := Decode([]byte(c.command))
+			sgr, ok := cmd.(*SetGraphicsRendition)
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, len(c.command), size)
+			assert.True(t, sgr.Colon)
+			assert.Equal(t, c.parameters, sgr.Parameters)
+
+			r, g, b, ok := sgr.RGB()
+			if c.r >= 0 {
+				assert.True(t, ok)
+				assert.Equal(t, []int{c.r, c.g, c.b}, []int{r, g, b})
+			} else {
+				assert.False(t, ok)
+			}
+
+			id, ok := sgr.ColorSpace()
+			assert.Equal(t, c.colorSpace >= 0, ok)
+			if ok {
+				assert.Equal(t, c.colorSpace, id)
+			}
+
+			index, ok := sgr.ColorIndex()
+			assert.Equal(t, c.index >= 0, ok)
+			if ok {
+				assert.Equal(t, c.index, index)
+			}
+
+			style, ok := sgr.UnderlineStyle()
+			assert.Equal(t, c.underline >= 0, ok)
+			if ok {
+				assert.Equal(t, c.underline, style)
+			}
+
+			var buf bytes.Buffer
+			encodedSize, err := sgr.Encode(&buf)
+			assert.NoError(t, err)
+			assert.Equal(t, size, encodedSize)
+			assert.Equal(t, c.command, buf.String())
+		})
+	}
+}
+
+// colon-delimited and semicolon-delimited parameters in a single control sequence
+func TestSGRList_SubParameters(t *testing.T) {
+	command := []byte("\x1b[1;4:3;38:2::255:0:0;48;5;17m")
+	cmd, size := Decode(command)
+	list, ok := cmd.(*SGRList)
+	assert.True(t, ok)
+	assert.Equal(t, len(command), size)
+	assert.Equal(t, SGRList{
+		{Command: SGRBold, Parameters: []int{}},
+		{Command: SGRUnderline, Parameters: []int{UnderlineCurly}, Colon: true},
+		{Command: SGRForegroundColor, Parameters: []int{2, -1, 255, 0, 0}, Colon: true},
+		{Command: SGRBackgroundColor, Parameters: []int{5, 17}},
+	}, *list)
+
+	var b bytes.Buffer
+	encodedSize, err := list.Encode(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, size, encodedSize)
+	assert.Equal(t, command, b.Bytes())
+}
+
+// hand-built extended colors with too few or too many parameters
+func TestSGR_ExtendedColor_WrongLength(t *testing.T) {
+	cases := [][]int{nil, {}, {5}, {5, 1, 2}, {2}, {2, 1}, {2, 1, 2}, {2, 1, 2, 3, 4, 5}, {3, 1}}
+	for _, command := range []int{SGRForegroundColor, SGRBackgroundColor, SGRUnderlineColor} {
+		for _, params := range cases {
+			sgr := &SetGraphicsRendition{Command: command, Parameters: params}
+
+			_, ok := sgr.ColorIndex()
+			assert.False(t, ok, "%v", params)
+			_, _, _, ok = sgr.RGB()
+			assert.False(t, ok, "%v", params)
+			_, ok = sgr.ColorSpace()
+			assert.False(t, ok, "%v", params)
+			_, ok = sgr.Color()
+			assert.False(t, ok, "%v", params)
+
+			var style Style
+			style.Apply(sgr)
+			assert.Equal(t, Style{}, style, "%v", params)
+		}
+	}
+}
+
+// malformed colon-delimited sub-parameters
+func TestSGR_SubParameters_Invalid(t *testing.T) {
+	assertUnrecognized(t, "\x1b[1:2m", "\x1b[4:6m", "\x1b[38:2:1:2m", "\x1b[38:5m", "\x1b[38;5:1m", "\x1b[38:3:1m", "\x1b[1:2A")
+}
@@ -0,0 +1,88 @@
+package ansicsi
+
+// sequenceHandler receives the text and control functions found by a sequenceSplitter.
+type sequenceHandler interface {
+	// handleText is called with each run of text.
+	handleText(text []byte) error
+	// handleCommand is called with each decoded control function and its raw bytes.
+	handleCommand(cmd Command, raw []byte) error
+}
+
+// sequenceSplitter splits a stream of data that is written in arbitrary chunks into runs of text and control
+// functions. Control sequences that are split across chunks are buffered until they are complete. Malformed control
+// sequences are discarded.
+type sequenceSplitter struct {
+	pending []byte
+}
+
+// split splits b into text and control functions and passes them to h. Any incomplete control sequence at the end of
+// b is buffered until the next call to split or flush. split returns the number of bytes of b that were passed to h
+// or buffered. If h returns an error, split stops and discards any buffered data.
+func (s *sequenceSplitter) split(b []byte, h sequenceHandler) (int, error) {
+	data, pendingSize := b, len(s.pending)
+	if pendingSize != 0 {
+		s.pending = append(s.pending, b...)
+		data = s.pending
+	}
+
+	// fail reports an error from h. The bytes of data before offset i have been handled.
+	fail := func(i int, err error) (int, error) {
+		s.pending = s.pending[:0]
+		return clamp(i-pendingSize, 0, len(b)), err
+	}
+
+	start := 0
+	for i := 0; i < len(data); {
+		if data[i] != 0x1b {
+			i++
+			continue
+		}
+
+		cmd, size, status := DecodeStatus(data[i:])
+		switch {
+		case status == StatusOK:
+			if err := s.text(data[start:i], h); err != nil {
+				return fail(start, err)
+			}
+			if err := h.handleCommand(cmd, data[i:i+size]); err != nil {
+				return fail(i, err)
+			}
+			i += size
+			start = i
+		case status == StatusInvalid:
+			if err := s.text(data[start:i], h); err != nil {
+				return fail(start, err)
+			}
+			i += size
+			start = i
+		case status == StatusIncomplete && len(data)-i < MaxScanSequenceSize:
+			if err := s.text(data[start:i], h); err != nil {
+				return fail(start, err)
+			}
+			s.pending = append(s.pending[:0], data[i:]...)
+			return len(b), nil
+		default:
+			i++
+		}
+	}
+
+	s.pending = s.pending[:0]
+	if err := s.text(data[start:], h); err != nil {
+		return fail(start, err)
+	}
+	return len(b), nil
+}
+
+// flush passes any buffered incomplete control sequence to h as text.
+func (s *sequenceSplitter) flush(h sequenceHandler) error {
+	pending := s.pending
+	s.pending = s.pending[:0]
+	return s.text(pending, h)
+}
+
+func (s *sequenceSplitter) text(b []byte, h sequenceHandler) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return h.handleText(b)
+}
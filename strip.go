@@ -0,0 +1,55 @@
+package ansicsi
+
+import "io"
+
+// KeepSGR is a StripWriter filter that keeps Set Graphics Rendition control functions and drops all others.
+func KeepSGR(cmd Command) bool {
+	switch cmd.(type) {
+	case *SetGraphicsRendition, *SGRList:
+		return true
+	default:
+		return false
+	}
+}
+
+// StripWriter is an io.Writer that removes control sequences from the data written to it and forwards the remaining
+// text to an underlying writer. Control sequences that are split across calls to Write are buffered until they are
+// complete. Malformed control sequences are always removed.
+//
+// StripWriter only removes control sequences that begin with a control sequence introducer (ESC [). Other escape
+// sequences, such as ESC ( B, which designates a character set, are not control sequences and are forwarded as text.
+type StripWriter struct {
+	w        io.Writer
+	keep     func(cmd Command) bool
+	splitter sequenceSplitter
+}
+
+// NewStripWriter returns a StripWriter that writes to w. If keep is non-nil, control sequences whose commands are
+// accepted by keep are forwarded to w unchanged; all other control sequences are removed. If keep is nil, all
+// control sequences are removed.
+func NewStripWriter(w io.Writer, keep func(cmd Command) bool) *StripWriter {
+	return &StripWriter{w: w, keep: keep}
+}
+
+// Write writes the text in b to the underlying writer, removing any control sequences that are not kept.
+func (s *StripWriter) Write(b []byte) (int, error) {
+	return s.splitter.split(b, s)
+}
+
+// Flush writes any buffered incomplete control sequence to the underlying writer as text.
+func (s *StripWriter) Flush() error {
+	return s.splitter.flush(s)
+}
+
+func (s *StripWriter) handleText(text []byte) error {
+	_, err := s.w.Write(text)
+	return err
+}
+
+func (s *StripWriter) handleCommand(cmd Command, raw []byte) error {
+	if s.keep == nil || !s.keep(cmd) {
+		return nil
+	}
+	_, err := s.w.Write(raw)
+	return err
+}
@@ -0,0 +1,125 @@
+package ansicsi
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripWriter(t *testing.T) {
+	input := "\x1b[1;31mHello\x1b[0m, \x1b[2J\x1b[10;1Hworld\x1b[1\n!\x1b[?25l"
+
+	cases := []struct {
+		name     string
+		keep     func(Command) bool
+		expected string
+	}{
+		{name: "all", expected: "Hello, world\n!"},
+		{name: "sgr", keep: KeepSGR, expected: "\x1b[1;31mHello\x1b[0m, world\n!"},
+		{name: "cursor", keep: func(cmd Command) bool {
+			_, ok := cmd.(*CursorPosition)
+			return ok
+		}, expected: "Hello, \x1b[10;1Hworld\n!"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Write the input in every possible pair of chunks.
+			for split := 0; split <= len(input); split++ {
+				var b bytes.Buffer
+				w := NewStripWriter(&b, c.keep)
+
+				n, err := w.Write([]byte(input[:split]))
+				assert.NoError(t, err)
+				assert.Equal(t, split, n)
+
+				n, err = w.Write([]byte(input[split:]))
+				assert.NoError(t, err)
+				assert.Equal(t, len(input)-split, n)
+
+				assert.NoError(t, w.Flush())
+				assert.Equal(t, c.expected, b.String(), "split at %d", split)
+			}
+		})
+	}
+}
+
+func TestStripWriter_ByteAtATime(t *testing.T) {
+	input := "\x1b[38;2;1;2;3mred\x1b[m \x1b[Kdone"
+
+	var b bytes.Buffer
+	w := NewStripWriter(&b, nil)
+	for i := range input {
+		_, err := w.Write([]byte{input[i]})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "red done", b.String())
+}
+
+func TestStripWriter_Flush(t *testing.T) {
+	var b bytes.Buffer
+	w := NewStripWriter(&b, nil)
+	_, err := w.Write([]byte("text\x1b[12"))
+	assert.NoError(t, err)
+	assert.Equal(t, "text", b.String())
+
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "text\x1b[12", b.String())
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write(b []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestStripWriter_Error(t *testing.T) {
+	w := NewStripWriter(errorWriter{}, nil)
+	_, err := w.Write([]byte("\x1b[1mtext"))
+	assert.EqualError(t, err, "write failed")
+}
+
+// toggleWriter is a writer that fails while fail is set.
+type toggleWriter struct {
+	bytes.Buffer
+	fail bool
+}
+
+func (w *toggleWriter) Write(b []byte) (int, error) {
+	if w.fail {
+		return 0, errors.New("write failed")
+	}
+	return w.Buffer.Write(b)
+}
+
+func TestStripWriter_PartialError(t *testing.T) {
+	var b toggleWriter
+	w := NewStripWriter(&b, nil)
+
+	n, err := w.Write([]byte("ab\x1b[1m\x1b[2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 9, n)
+
+	// The buffered ESC[2 and the J that completes it are handled before the write of cd fails.
+	b.fail = true
+	n, err = w.Write([]byte("Jcd"))
+	assert.EqualError(t, err, "write failed")
+	assert.Equal(t, 1, n)
+
+	b.fail = false
+	n, err = w.Write([]byte("ef"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "abef", b.String())
+}
+
+func TestStripWriter_OtherEscapeSequences(t *testing.T) {
+	var b bytes.Buffer
+	w := NewStripWriter(&b, nil)
+	_, err := w.Write([]byte("\x1b[1mbold\x1b(B\x1b[m"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bold\x1b(B", b.String())
+}
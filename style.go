@@ -0,0 +1,262 @@
+package ansicsi
+
+import (
+	"bytes"
+	"io"
+)
+
+const (
+	BlinkNone  = 0 // Steady (not blinking)
+	BlinkSlow  = 1 // Slowly blinking (less then 150 per minute)
+	BlinkRapid = 2 // Rapidly blinking (150 per minute or more)
+)
+
+// Style describes the graphics rendition selected by a sequence of Set Graphics Rendition control functions. The zero
+// value is the default rendition.
+type Style struct {
+	Bold                bool  // SGRBold, cancelled by SGRNormalWeight
+	Faint               bool  // SGRFaint, cancelled by SGRNormalWeight
+	Italic              bool  // SGRItalic, cancelled by SGRNoItalicOrFraktur
+	Fraktur             bool  // SGRFraktur, cancelled by SGRNoItalicOrFraktur
+	Underline           int   // One of the Underline* constants
+	Blink               int   // One of the Blink* constants
+	Inverse             bool  // SGRInverse, cancelled by SGRNoInverse
+	Conceal             bool  // SGRConceal, cancelled by SGRNoConceal
+	Strikethrough       bool  // SGRStrikethrough, cancelled by SGRNoStrikethrough
+	Font                int   // 0 for the primary font, or 1-9 for SGRAlternativeFont1-SGRAlternativeFont9
+	ProportionalSpacing bool  // SGRProportionalSpacing, cancelled by SGRNoProportionalSpacing
+	Framed              bool  // SGRFrame, cancelled by SGRNoFrameOrEncircle
+	Encircled           bool  // SGREncircle, cancelled by SGRNoFrameOrEncircle
+	Overline            bool  // SGROverline, cancelled by SGRNoOverline
+	Ideogram            int   // 0, or one of SGRIdeogramUnderline-SGRIdeogramStress; cancelled by SGRIdeogramReset
+	Foreground          Color // The foreground color
+	Background          Color // The background color
+	UnderlineColor      Color // The underline color
+}
+
+// ApplyCommand applies the given command to the style if it is a *SetGraphicsRendition or an *SGRList. All other
+// commands are ignored.
+func (s *Style) ApplyCommand(cmd Command) {
+	switch cmd := cmd.(type) {
+	case *SetGraphicsRendition:
+		s.Apply(cmd)
+	case *SGRList:
+		for i := range *cmd {
+			s.Apply(&(*cmd)[i])
+		}
+	}
+}
+
+// Apply updates the style to reflect the given Set Graphics Rendition control function. Commands that are not
+// recognized or that carry invalid parameters are ignored.
+func (s *Style) Apply(sgr *SetGraphicsRendition) {
+	switch command := sgr.Command; command {
+	case SGRReset:
+		*s = Style{}
+	case SGRBold:
+		s.Bold = true
+	case SGRFaint:
+		s.Faint = true
+	case SGRItalic:
+		s.Italic = true
+	case SGRUnderline, SGRDoubleUnderline, SGRNoUnderline:
+		if style, ok := sgr.UnderlineStyle(); ok && style >= UnderlineNone && style <= UnderlineDashed {
+			s.Underline = style
+		}
+	case SGRSlowBlink:
+		s.Blink = BlinkSlow
+	case SGRRapidBlink:
+		s.Blink = BlinkRapid
+	case SGRInverse:
+		s.Inverse = true
+	case SGRConceal:
+		s.Conceal = true
+	case SGRStrikethrough:
+		s.Strikethrough = true
+	case SGRDefaultFont, SGRAlternativeFont1, SGRAlternativeFont2, SGRAlternativeFont3, SGRAlternativeFont4,
+		SGRAlternativeFont5, SGRAlternativeFont6, SGRAlternativeFont7, SGRAlternativeFont8, SGRAlternativeFont9:
+		s.Font = command - SGRDefaultFont
+	case SGRFraktur:
+		s.Fraktur = true
+	case SGRNormalWeight:
+		s.Bold, s.Faint = false, false
+	case SGRNoItalicOrFraktur:
+		s.Italic, s.Fraktur = false, false
+	case SGRNoBlink:
+		s.Blink = BlinkNone
+	case SGRProportionalSpacing:
+		s.ProportionalSpacing = true
+	case SGRNoInverse:
+		s.Inverse = false
+	case SGRNoConceal:
+		s.Conceal = false
+	case SGRNoStrikethrough:
+		s.Strikethrough = false
+	case SGRForegroundBlack, SGRForegroundRed, SGRForegroundGreen, SGRForegroundYellow, SGRForegroundBlue,
+		SGRForegroundMagenta, SGRForegroundCyan, SGRForegroundWhite, SGRForegroundColor, SGRForegroundDefault:
+		if c, ok := sgr.Color(); ok {
+			s.Foreground = c
+		}
+	case SGRBackgroundBlack, SGRBackgroundRed, SGRBackgroundGreen, SGRBackgroundYellow, SGRBackgroundBlue,
+		SGRBackgroundMagenta, SGRBackgroundCyan, SGRBackgroundWhite, SGRBackgroundColor, SGRBackgroundDefault:
+		if c, ok := sgr.Color(); ok {
+			s.Background = c
+		}
+	case SGRNoProportionalSpacing:
+		s.ProportionalSpacing = false
+	case SGRFrame:
+		s.Framed = true
+	case SGREncircle:
+		s.Encircled = true
+	case SGROverline:
+		s.Overline = true
+	case SGRNoFrameOrEncircle:
+		s.Framed, s.Encircled = false, false
+	case SGRNoOverline:
+		s.Overline = false
+	case SGRUnderlineColor, SGRDefaultUnderlineColor:
+		if c, ok := sgr.Color(); ok {
+			s.UnderlineColor = c
+		}
+	case SGRIdeogramUnderline, SGRIdeogramDoubleUnderline, SGRIdeogramOverline, SGRIdeogramDoubleOverline,
+		SGRIdeogramStress:
+		s.Ideogram = command
+	case SGRIdeogramReset:
+		s.Ideogram = 0
+	case SGRForegroundBrightBlack, SGRForegroundBrightRed, SGRForegroundBrightGreen, SGRForegroundBrightYellow,
+		SGRForegroundBrightBlue, SGRForegroundBrightMagenta, SGRForegroundBrightCyan, SGRForegroundBrightWhite:
+		s.Foreground, _ = sgr.Color()
+	case SGRBackgroundBrightBlack, SGRBackgroundBrightRed, SGRBackgroundBrightGreen, SGRBackgroundBrightYellow,
+		SGRBackgroundBrightBlue, SGRBackgroundBrightMagenta, SGRBackgroundBrightCyan, SGRBackgroundBrightWhite:
+		s.Background, _ = sgr.Color()
+	}
+}
+
+// EncodeStyleChange writes the shortest Set Graphics Rendition control sequence that changes the graphics rendition
+// of a terminal from one style to another. Depending on the differences between the two styles, the sequence either
+// cancels and selects individual aspects or resets the rendition and then selects every aspect of the new style.
+// Fields that are outside their valid range are treated as their default values. If the styles are then equal,
+// nothing is written.
+func EncodeStyleChange(w io.Writer, from, to Style) (int, error) {
+	from, to = from.normalize(), to.normalize()
+	if from == to {
+		return 0, nil
+	}
+
+	var targeted, reset bytes.Buffer
+	targetedList := styleChange(from, to)
+	if _, err := targetedList.Encode(&targeted); err != nil {
+		return 0, err
+	}
+	resetList := append(SGRList{{Command: SGRReset, Parameters: []int{}}}, styleChange(Style{}, to)...)
+	if _, err := resetList.Encode(&reset); err != nil {
+		return 0, err
+	}
+
+	if reset.Len() < targeted.Len() {
+		return w.Write(reset.Bytes())
+	}
+	return w.Write(targeted.Bytes())
+}
+
+// normalize returns a copy of the style in which each field that is outside its valid range is replaced with its
+// default value. An ANSI underline color is replaced with the equivalent indexed color, which is how Apply records the
+// control function that UnderlineColor returns for it.
+func (s Style) normalize() Style {
+	if s.UnderlineColor.Kind() == ColorANSI {
+		s.UnderlineColor = IndexedColor(uint8(s.UnderlineColor.Index()))
+	}
+	if s.Underline < UnderlineNone || s.Underline > UnderlineDashed {
+		s.Underline = UnderlineNone
+	}
+	if s.Blink < BlinkNone || s.Blink > BlinkRapid {
+		s.Blink = BlinkNone
+	}
+	if s.Font < 0 || s.Font > SGRAlternativeFont9-SGRDefaultFont {
+		s.Font = 0
+	}
+	if s.Ideogram < SGRIdeogramUnderline || s.Ideogram > SGRIdeogramStress {
+		s.Ideogram = 0
+	}
+	return s
+}
+
+// styleChange returns the list of Set Graphics Rendition control functions that cancel or select the aspects of the
+// graphics rendition that differ between from and to. Both styles must be normalized.
+func styleChange(from, to Style) SGRList {
+	var list SGRList
+	add := func(command int, params ...int) {
+		if params == nil {
+			params = []int{}
+		}
+		list = append(list, SetGraphicsRendition{Command: command, Parameters: params})
+	}
+	flag := func(from, to bool, set, cancel int) {
+		switch {
+		case to && !from:
+			add(set)
+		case from && !to:
+			add(cancel)
+		}
+	}
+	// pair handles two aspects that are cancelled by a single command.
+	pair := func(fromA, fromB, toA, toB bool, setA, setB, cancel int) {
+		if fromA && !toA || fromB && !toB {
+			add(cancel)
+			fromA, fromB = false, false
+		}
+		flag(fromA, toA, setA, cancel)
+		flag(fromB, toB, setB, cancel)
+	}
+
+	pair(from.Bold, from.Faint, to.Bold, to.Faint, SGRBold, SGRFaint, SGRNormalWeight)
+	pair(from.Italic, from.Fraktur, to.Italic, to.Fraktur, SGRItalic, SGRFraktur, SGRNoItalicOrFraktur)
+	if from.Underline != to.Underline {
+		switch to.Underline {
+		case UnderlineNone:
+			add(SGRNoUnderline)
+		case UnderlineSingle:
+			add(SGRUnderline)
+		case UnderlineDouble:
+			add(SGRDoubleUnderline)
+		default:
+			list = append(list, SetGraphicsRendition{Command: SGRUnderline, Parameters: []int{to.Underline}, Colon: true})
+		}
+	}
+	if from.Blink != to.Blink {
+		switch to.Blink {
+		case BlinkNone:
+			add(SGRNoBlink)
+		case BlinkSlow:
+			add(SGRSlowBlink)
+		case BlinkRapid:
+			add(SGRRapidBlink)
+		}
+	}
+	flag(from.Inverse, to.Inverse, SGRInverse, SGRNoInverse)
+	flag(from.Conceal, to.Conceal, SGRConceal, SGRNoConceal)
+	flag(from.Strikethrough, to.Strikethrough, SGRStrikethrough, SGRNoStrikethrough)
+	if from.Font != to.Font {
+		add(SGRDefaultFont + to.Font)
+	}
+	flag(from.ProportionalSpacing, to.ProportionalSpacing, SGRProportionalSpacing, SGRNoProportionalSpacing)
+	pair(from.Framed, from.Encircled, to.Framed, to.Encircled, SGRFrame, SGREncircle, SGRNoFrameOrEncircle)
+	flag(from.Overline, to.Overline, SGROverline, SGRNoOverline)
+	if from.Ideogram != to.Ideogram {
+		if to.Ideogram == 0 {
+			add(SGRIdeogramReset)
+		} else {
+			add(to.Ideogram)
+		}
+	}
+	if from.Foreground != to.Foreground {
+		list = append(list, *ForegroundColor(to.Foreground))
+	}
+	if from.Background != to.Background {
+		list = append(list, *BackgroundColor(to.Background))
+	}
+	if from.UnderlineColor != to.UnderlineColor {
+		list = append(list, *UnderlineColor(to.UnderlineColor))
+	}
+	return list
+}
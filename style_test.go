@@ -0,0 +1,153 @@
+package ansicsi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func applyAll(s *Style, input string) {
+	for b := []byte(input); len(b) > 0; {
+		cmd, size := Decode(b)
+		if size == 0 {
+			b = b[1:]
+			continue
+		}
+		s.ApplyCommand(cmd)
+		b = b[size:]
+	}
+}
+
+func TestStyle_Apply(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Style
+	}{
+		{input: "\x1b[1;2m", expected: Style{Bold: true, Faint: true}},
+		{input: "\x1b[1;2;22m", expected: Style{}},
+		{input: "\x1b[3;20m", expected: Style{Italic: true, Fraktur: true}},
+		{input: "\x1b[3;20;23m", expected: Style{}},
+		{input: "\x1b[4m", expected: Style{Underline: UnderlineSingle}},
+		{input: "\x1b[21m", expected: Style{Underline: UnderlineDouble}},
+		{input: "\x1b[4:3m", expected: Style{Underline: UnderlineCurly}},
+		{input: "\x1b[4;24m", expected: Style{}},
+		{input: "\x1b[5m", expected: Style{Blink: BlinkSlow}},
+		{input: "\x1b[5;6m", expected: Style{Blink: BlinkRapid}},
+		{input: "\x1b[6;25m", expected: Style{}},
+		{input: "\x1b[7;8;9m", expected: Style{Inverse: true, Conceal: true, Strikethrough: true}},
+		{input: "\x1b[7;8;9;27;28;29m", expected: Style{}},
+		{input: "\x1b[13m", expected: Style{Font: 3}},
+		{input: "\x1b[19;10m", expected: Style{}},
+		{input: "\x1b[26m", expected: Style{ProportionalSpacing: true}},
+		{input: "\x1b[26;50m", expected: Style{}},
+		{input: "\x1b[51;52;53m", expected: Style{Framed: true, Encircled: true, Overline: true}},
+		{input: "\x1b[51;52;53;54;55m", expected: Style{}},
+		{input: "\x1b[62m", expected: Style{Ideogram: SGRIdeogramOverline}},
+		{input: "\x1b[64;65m", expected: Style{}},
+		{input: "\x1b[31;42m", expected: Style{
+			Foreground: ANSIColor(1),
+			Background: ANSIColor(2),
+		}},
+		{input: "\x1b[38;5;200;48;2;1;2;3;58:2::4:5:6m", expected: Style{
+			Foreground:     IndexedColor(200),
+			Background:     RGBColor(1, 2, 3),
+			UnderlineColor: RGBColor(4, 5, 6),
+		}},
+		{input: "\x1b[91;107m", expected: Style{Foreground: BrightColor(1), Background: BrightColor(7)}},
+		{input: "\x1b[31;42;58;5;1m\x1b[39;49;59m", expected: Style{}},
+		{input: "\x1b[38;5;300m", expected: Style{}},
+		{input: "\x1b[1;3;4;31m\x1b[0m", expected: Style{}},
+		{input: "\x1b[1m\x1b[m", expected: Style{}},
+		{input: "\x1b[1m\x1b[2J\x1b[3m", expected: Style{Bold: true, Italic: true}},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			var s Style
+			applyAll(&s, c.input)
+			assert.Equal(t, c.expected, s)
+		})
+	}
+}
+
+func TestEncodeStyleChange(t *testing.T) {
+	red := ANSIColor(1)
+	cases := []struct {
+		from, to Style
+		expected string
+	}{
+		{from: Style{Bold: true}, to: Style{Bold: true}, expected: ""},
+		{from: Style{}, to: Style{Bold: true}, expected: "\x1b[1m"},
+		{from: Style{Bold: true, Italic: true}, to: Style{Italic: true}, expected: "\x1b[22m"},
+		{from: Style{Bold: true, Faint: true}, to: Style{Faint: true}, expected: "\x1b[0;2m"},
+		{from: Style{Bold: true, Faint: true, Italic: true}, to: Style{Faint: true, Italic: true}, expected: "\x1b[22;2m"},
+		{from: Style{Underline: UnderlineSingle}, to: Style{Underline: UnderlineCurly}, expected: "\x1b[4:3m"},
+		{from: Style{Foreground: red}, to: Style{Foreground: red, Background: red}, expected: "\x1b[41m"},
+		{
+			from:     Style{Bold: true, Italic: true, Underline: UnderlineSingle, Foreground: red},
+			to:       Style{},
+			expected: "\x1b[0m",
+		},
+		{
+			from:     Style{Bold: true, Italic: true, Inverse: true, Foreground: red},
+			to:       Style{Strikethrough: true},
+			expected: "\x1b[0;9m",
+		},
+		{
+			from:     Style{},
+			to:       Style{UnderlineColor: red, Background: RGBColor(1, 2, 3)},
+			expected: "\x1b[48;2;1;2;3;58;5;1m",
+		},
+		{from: Style{}, to: Style{Font: 12}, expected: ""},
+		{from: Style{Font: 3, Bold: true}, to: Style{Font: 12, Bold: true}, expected: "\x1b[10m"},
+		{from: Style{Font: -1}, to: Style{Font: 9}, expected: "\x1b[19m"},
+		{from: Style{Blink: 7}, to: Style{Blink: 9}, expected: ""},
+		{from: Style{Blink: 7, Bold: true, Italic: true}, to: Style{Blink: 9, Italic: true}, expected: "\x1b[22m"},
+		{from: Style{Blink: BlinkSlow, Italic: true}, to: Style{Blink: 9, Italic: true}, expected: "\x1b[25m"},
+		{from: Style{}, to: Style{Underline: 6}, expected: ""},
+		{from: Style{Ideogram: 1}, to: Style{Ideogram: 70}, expected: ""},
+		{from: Style{Ideogram: SGRIdeogramStress, Italic: true}, to: Style{Ideogram: 1, Italic: true}, expected: "\x1b[65m"},
+	}
+	for _, c := range cases {
+		var b bytes.Buffer
+		n, err := EncodeStyleChange(&b, c.from, c.to)
+		assert.NoError(t, err)
+		assert.Equal(t, len(c.expected), n)
+		assert.Equal(t, c.expected, b.String())
+	}
+}
+
+func TestEncodeStyleChange_RoundTrip(t *testing.T) {
+	styles := []Style{
+		{},
+		{Bold: true, Faint: true, Italic: true, Fraktur: true},
+		{Underline: UnderlineDashed, Blink: BlinkRapid, Inverse: true, Conceal: true, Strikethrough: true},
+		{Font: 4, ProportionalSpacing: true, Framed: true, Encircled: true, Overline: true},
+		{Ideogram: SGRIdeogramStress, Bold: true},
+		{Encircled: true, Underline: UnderlineDouble, Blink: BlinkSlow},
+		{
+			Foreground:     ANSIColor(7),
+			Background:     IndexedColor(99),
+			UnderlineColor: RGBColor(10, 20, 30),
+		},
+		{Foreground: RGBColor(1, 2, 3), UnderlineColor: ANSIColor(3)},
+		{Foreground: BrightColor(3), Background: BrightColor(4), UnderlineColor: BrightColor(5)},
+	}
+	for i, from := range styles {
+		for j, to := range styles {
+			var b bytes.Buffer
+			_, err := EncodeStyleChange(&b, from, to)
+			assert.NoError(t, err)
+
+			s := from
+			applyAll(&s, b.String())
+			assert.Equal(t, to.normalize(), s.normalize(), "%d -> %d: %q", i, j, b.String())
+
+			// Applying the change reproduces to, so there is nothing left to change.
+			b.Reset()
+			_, err = EncodeStyleChange(&b, s, to)
+			assert.NoError(t, err)
+			assert.Empty(t, b.String(), "%d -> %d", i, j)
+		}
+	}
+}
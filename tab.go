@@ -0,0 +1,218 @@
+package ansicsi
+
+import "io"
+
+const (
+	TabClearCharacter       = 0 // Clear the character tabulation stop at the active position
+	TabClearLine            = 1 // Clear the line tabulation stop at the active line
+	TabClearCharacterInLine = 2 // Clear all character tabulation stops in the active line
+	TabClearAllCharacter    = 3 // Clear all character tabulation stops
+	TabClearAllLine         = 4 // Clear all line tabulation stops
+	TabClearAll             = 5 // Clear all tabulation stops
+)
+
+const (
+	TabControlSetCharacter         = 0 // Set a character tabulation stop at the active position
+	TabControlSetLine              = 1 // Set a line tabulation stop at the active line
+	TabControlClearCharacter       = 2 // Clear the character tabulation stop at the active position
+	TabControlClearLine            = 3 // Clear the line tabulation stop at the active line
+	TabControlClearCharacterInLine = 4 // Clear all character tabulation stops in the active line
+	TabControlClearAllCharacter    = 5 // Clear all character tabulation stops
+	TabControlClearAllLine         = 6 // Clear all line tabulation stops
+)
+
+// CursorForwardTabulation represents a CURSOR FORWARD TABULATION (CHT) control function, which moves the active
+// position forward by the given number of character tabulation stops.
+type CursorForwardTabulation struct {
+	// N is the number of tabulation stops to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorForwardTabulation) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x49)
+}
+
+func (c *CursorForwardTabulation) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorBackwardTabulation represents a CURSOR BACKWARD TABULATION (CBT) control function, which moves the active
+// position backward by the given number of character tabulation stops.
+type CursorBackwardTabulation struct {
+	// N is the number of tabulation stops to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorBackwardTabulation) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x5a)
+}
+
+func (c *CursorBackwardTabulation) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// CursorLineTabulation represents a CURSOR LINE TABULATION (CVT) control function, which moves the active position
+// down by the given number of line tabulation stops.
+type CursorLineTabulation struct {
+	// N is the number of line tabulation stops to move. Defaults to 1.
+	N int
+}
+
+func (c *CursorLineTabulation) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{c.N}, nil, 0x59)
+}
+
+func (c *CursorLineTabulation) decodeParameters(params []int) bool {
+	return decodePn(params, &c.N)
+}
+
+// TabulationClear represents a TABULATION CLEAR (TBC) control function.
+type TabulationClear struct {
+	// Mode selects the tabulation stops to clear. One of the TabClear* constants. Defaults to TabClearCharacter.
+	Mode int
+}
+
+func (t *TabulationClear) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, []int{t.Mode}, nil, 0x67)
+}
+
+func (t *TabulationClear) decodeParameters(params []int) bool {
+	return decodePs(params, &t.Mode) && t.Mode <= TabClearAll
+}
+
+// CursorTabulationControl represents a CURSOR TABULATION CONTROL (CTC) control function, which sets or clears
+// tabulation stops.
+type CursorTabulationControl struct {
+	// Actions lists the changes to make. Each is one of the TabControl* constants. Defaults to a single
+	// TabControlSetCharacter.
+	Actions []int
+}
+
+func (c *CursorTabulationControl) Encode(w io.Writer) (int, error) {
+	return encodeCommand(w, c.Actions, nil, 0x57)
+}
+
+func (c *CursorTabulationControl) decodeParameters(params []int) bool {
+	if len(params) == 0 {
+		params = []int{TabControlSetCharacter}
+	}
+	c.Actions = make([]int, len(params))
+	for i, p := range params {
+		if p < 0 {
+			p = TabControlSetCharacter
+		}
+		if p > TabControlClearAllLine {
+			return false
+		}
+		c.Actions[i] = p
+	}
+	return true
+}
+
+// TabStops is a set of character tabulation stops for a line of a fixed width. TabStops does not model line
+// tabulation stops.
+//
+// A HORIZONTAL TABULATION SET (HTS) control function, which is not a control sequence, corresponds to a call to Set
+// with the active position.
+type TabStops struct {
+	stops []bool
+}
+
+// NewTabStops returns a set of tabulation stops for a line with the given number of columns. The set initially has a
+// stop every eight columns, which is the default for most terminals. A negative width is treated as 0.
+func NewTabStops(width int) *TabStops {
+	if width < 0 {
+		width = 0
+	}
+	t := &TabStops{stops: make([]bool, width)}
+	t.Reset()
+	return t
+}
+
+// Reset restores the default tabulation stops.
+func (t *TabStops) Reset() {
+	for i := range t.stops {
+		t.stops[i] = i != 0 && i%8 == 0
+	}
+}
+
+// IsSet returns true if there is a tabulation stop at the given 0-based column.
+func (t *TabStops) IsSet(column int) bool {
+	return column >= 0 && column < len(t.stops) && t.stops[column]
+}
+
+// Set sets a tabulation stop at the given 0-based column.
+func (t *TabStops) Set(column int) {
+	if column >= 0 && column < len(t.stops) {
+		t.stops[column] = true
+	}
+}
+
+// Clear clears the tabulation stop at the given 0-based column, if any.
+func (t *TabStops) Clear(column int) {
+	if column >= 0 && column < len(t.stops) {
+		t.stops[column] = false
+	}
+}
+
+// ClearAll clears all tabulation stops.
+func (t *TabStops) ClearAll() {
+	for i := range t.stops {
+		t.stops[i] = false
+	}
+}
+
+// Next returns the column of the nth tabulation stop after the given 0-based column. If there are fewer than n stops
+// after the column, Next returns the last column.
+func (t *TabStops) Next(column, n int) int {
+	for column < len(t.stops)-1 && n > 0 {
+		column++
+		if t.stops[column] {
+			n--
+		}
+	}
+	return column
+}
+
+// Previous returns the column of the nth tabulation stop before the given 0-based column. If there are fewer than n
+// stops before the column, Previous returns the first column.
+func (t *TabStops) Previous(column, n int) int {
+	for column > 0 && n > 0 {
+		column--
+		if t.IsSet(column) {
+			n--
+		}
+	}
+	return column
+}
+
+// Apply updates the tabulation stops to reflect the given command with the active position at the given 0-based
+// column, and returns the new active column. CursorForwardTabulation and CursorBackwardTabulation move the active
+// column; TabulationClear and CursorTabulationControl set or clear stops. All other commands are ignored.
+func (t *TabStops) Apply(cmd Command, column int) int {
+	switch cmd := cmd.(type) {
+	case *CursorForwardTabulation:
+		return t.Next(column, cmd.N)
+	case *CursorBackwardTabulation:
+		return t.Previous(column, cmd.N)
+	case *TabulationClear:
+		switch cmd.Mode {
+		case TabClearCharacter:
+			t.Clear(column)
+		case TabClearCharacterInLine, TabClearAllCharacter, TabClearAll:
+			t.ClearAll()
+		}
+	case *CursorTabulationControl:
+		for _, action := range cmd.Actions {
+			switch action {
+			case TabControlSetCharacter:
+				t.Set(column)
+			case TabControlClearCharacter:
+				t.Clear(column)
+			case TabControlClearCharacterInLine, TabControlClearAllCharacter:
+				t.ClearAll()
+			}
+		}
+	}
+	return column
+}
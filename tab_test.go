@@ -0,0 +1,98 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTabulation(t *testing.T) {
+	cases := []roundTripCase{
+		{command: "\x1b[2I", cmd: &CursorForwardTabulation{N: 2}},
+		{command: "\x1b[I", cmd: &CursorForwardTabulation{N: 1}, encoded: "\x1b[1I"},
+		{command: "\x1b[3Z", cmd: &CursorBackwardTabulation{N: 3}},
+		{command: "\x1b[0Z", cmd: &CursorBackwardTabulation{N: 1}, encoded: "\x1b[1Z"},
+		{command: "\x1b[4Y", cmd: &CursorLineTabulation{N: 4}},
+		{command: "\x1b[g", cmd: &TabulationClear{Mode: TabClearCharacter}, encoded: "\x1b[0g"},
+		{command: "\x1b[3g", cmd: &TabulationClear{Mode: TabClearAllCharacter}},
+		{command: "\x1b[W", cmd: &CursorTabulationControl{Actions: []int{TabControlSetCharacter}}, encoded: "\x1b[0W"},
+		{command: "\x1b[5W", cmd: &CursorTabulationControl{Actions: []int{TabControlClearAllCharacter}}},
+		{
+			command: "\x1b[5;;1W",
+			cmd: &CursorTabulationControl{
+				Actions: []int{TabControlClearAllCharacter, TabControlSetCharacter, TabControlSetLine},
+			},
+			encoded: "\x1b[5;0;1W",
+		},
+	}
+	assertRoundTrip(t, cases)
+}
+
+func TestTabulation_Invalid(t *testing.T) {
+	assertUnrecognized(t, "\x1b[6g", "\x1b[1;2I", "\x1b[7W", "\x1b[0;7W")
+}
+
+func TestTabStops(t *testing.T) {
+	tabs := NewTabStops(30)
+	assert.False(t, tabs.IsSet(0))
+	assert.True(t, tabs.IsSet(8))
+	assert.True(t, tabs.IsSet(24))
+	assert.Equal(t, 8, tabs.Next(0, 1))
+	assert.Equal(t, 16, tabs.Next(8, 1))
+	assert.Equal(t, 24, tabs.Next(3, 3))
+	assert.Equal(t, 29, tabs.Next(3, 4))
+	assert.Equal(t, 29, tabs.Next(29, 1))
+	assert.Equal(t, 16, tabs.Previous(20, 1))
+	assert.Equal(t, 8, tabs.Previous(16, 1))
+	assert.Equal(t, 0, tabs.Previous(16, 3))
+
+	tabs.Set(4)
+	tabs.Clear(16)
+	assert.Equal(t, 4, tabs.Next(0, 1))
+	assert.Equal(t, 24, tabs.Next(8, 1))
+	assert.Equal(t, 4, tabs.Previous(8, 1))
+
+	tabs.ClearAll()
+	assert.Equal(t, 29, tabs.Next(0, 1))
+	assert.Equal(t, 0, tabs.Previous(29, 1))
+
+	tabs.Reset()
+	assert.False(t, tabs.IsSet(4))
+	assert.True(t, tabs.IsSet(16))
+
+	tabs.Set(-1)
+	tabs.Set(30)
+	assert.False(t, tabs.IsSet(-1))
+	assert.False(t, tabs.IsSet(30))
+}
+
+func TestTabStops_NegativeWidth(t *testing.T) {
+	tabs := NewTabStops(-1)
+	tabs.Set(0)
+	assert.False(t, tabs.IsSet(0))
+	assert.Equal(t, 0, tabs.Next(0, 1))
+	assert.Equal(t, 0, tabs.Previous(3, 1))
+}
+
+func TestTabStops_Apply(t *testing.T) {
+	tabs := NewTabStops(40)
+	assert.Equal(t, 16, tabs.Apply(&CursorForwardTabulation{N: 2}, 3))
+	assert.Equal(t, 8, tabs.Apply(&CursorBackwardTabulation{N: 1}, 16))
+	assert.Equal(t, 5, tabs.Apply(&CursorLineTabulation{N: 1}, 5))
+
+	assert.Equal(t, 5, tabs.Apply(&CursorTabulationControl{Actions: []int{TabControlSetCharacter}}, 5))
+	assert.True(t, tabs.IsSet(5))
+	tabs.Apply(&CursorTabulationControl{Actions: []int{TabControlClearCharacter}}, 5)
+	assert.False(t, tabs.IsSet(5))
+
+	tabs.Apply(&TabulationClear{Mode: TabClearCharacter}, 8)
+	assert.False(t, tabs.IsSet(8))
+	assert.True(t, tabs.IsSet(16))
+	tabs.Apply(&TabulationClear{Mode: TabClearLine}, 16)
+	assert.True(t, tabs.IsSet(16))
+	tabs.Apply(&TabulationClear{Mode: TabClearAll}, 0)
+	assert.False(t, tabs.IsSet(16))
+
+	tabs.Apply(&CursorTabulationControl{Actions: []int{TabControlSetCharacter, TabControlClearAllCharacter}}, 3)
+	assert.False(t, tabs.IsSet(3))
+}
@@ -0,0 +1,156 @@
+package ansicsi
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRunes lists the East Asian Wide (W) and Fullwidth (F) runes, which occupy two columns.
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115f, 1}, {0x231a, 0x231b, 1}, {0x2329, 0x232a, 1}, {0x23e9, 0x23ec, 1}, {0x23f0, 0x23f0, 1},
+		{0x23f3, 0x23f3, 1}, {0x25fd, 0x25fe, 1}, {0x2614, 0x2615, 1}, {0x2648, 0x2653, 1}, {0x267f, 0x267f, 1},
+		{0x2693, 0x2693, 1}, {0x26a1, 0x26a1, 1}, {0x26aa, 0x26ab, 1}, {0x26bd, 0x26be, 1}, {0x26c4, 0x26c5, 1},
+		{0x26ce, 0x26ce, 1}, {0x26d4, 0x26d4, 1}, {0x26ea, 0x26ea, 1}, {0x26f2, 0x26f3, 1}, {0x26f5, 0x26f5, 1},
+		{0x26fa, 0x26fa, 1}, {0x26fd, 0x26fd, 1}, {0x2705, 0x2705, 1}, {0x270a, 0x270b, 1}, {0x2728, 0x2728, 1},
+		{0x274c, 0x274c, 1}, {0x274e, 0x274e, 1}, {0x2753, 0x2755, 1}, {0x2757, 0x2757, 1}, {0x2795, 0x2797, 1},
+		{0x27b0, 0x27b0, 1}, {0x27bf, 0x27bf, 1}, {0x2b1b, 0x2b1c, 1}, {0x2b50, 0x2b50, 1}, {0x2b55, 0x2b55, 1},
+		{0x2e80, 0x303e, 1}, {0x3041, 0x33ff, 1}, {0x3400, 0x4dbf, 1}, {0x4e00, 0x9fff, 1}, {0xa000, 0xa4cf, 1},
+		{0xa960, 0xa97f, 1}, {0xac00, 0xd7a3, 1}, {0xf900, 0xfaff, 1}, {0xfe10, 0xfe19, 1}, {0xfe30, 0xfe6f, 1},
+		{0xff00, 0xff60, 1}, {0xffe0, 0xffe6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x16fe0, 0x16fe4, 1}, {0x17000, 0x187f7, 1}, {0x18800, 0x18cd5, 1}, {0x1b000, 0x1b2ff, 1},
+		{0x1f004, 0x1f004, 1}, {0x1f0cf, 0x1f0cf, 1}, {0x1f18e, 0x1f18e, 1}, {0x1f191, 0x1f19a, 1},
+		{0x1f200, 0x1f202, 1}, {0x1f210, 0x1f23b, 1}, {0x1f240, 0x1f248, 1}, {0x1f250, 0x1f251, 1},
+		{0x1f260, 0x1f265, 1}, {0x1f300, 0x1f320, 1}, {0x1f32d, 0x1f335, 1}, {0x1f337, 0x1f37c, 1},
+		{0x1f37e, 0x1f393, 1}, {0x1f3a0, 0x1f3ca, 1}, {0x1f3cf, 0x1f3d3, 1}, {0x1f3e0, 0x1f3f0, 1},
+		{0x1f3f4, 0x1f3f4, 1}, {0x1f3f8, 0x1f43e, 1}, {0x1f440, 0x1f440, 1}, {0x1f442, 0x1f4fc, 1},
+		{0x1f4ff, 0x1f53d, 1}, {0x1f54b, 0x1f54e, 1}, {0x1f550, 0x1f567, 1}, {0x1f57a, 0x1f57a, 1},
+		{0x1f595, 0x1f596, 1}, {0x1f5a4, 0x1f5a4, 1}, {0x1f5fb, 0x1f64f, 1}, {0x1f680, 0x1f6c5, 1},
+		{0x1f6cc, 0x1f6cc, 1}, {0x1f6d0, 0x1f6d2, 1}, {0x1f6d5, 0x1f6d7, 1}, {0x1f6eb, 0x1f6ec, 1},
+		{0x1f6f4, 0x1f6fc, 1}, {0x1f7e0, 0x1f7eb, 1}, {0x1f90c, 0x1f93a, 1}, {0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1f9ff, 1}, {0x1fa70, 0x1faff, 1}, {0x20000, 0x2fffd, 1}, {0x30000, 0x3fffd, 1},
+	},
+}
+
+// zeroWidthRunes lists the runes that do not occupy a column: combining marks, format characters, and the Hangul
+// medial vowels and final consonants.
+var zeroWidthRunes = []*unicode.RangeTable{
+	unicode.Mn,
+	unicode.Me,
+	unicode.Cf,
+	{R16: []unicode.Range16{{0x1160, 0x11ff, 1}}},
+}
+
+// RuneWidth returns the number of columns occupied by r when it is displayed in a terminal. Control characters,
+// combining marks, and format characters occupy no columns; East Asian Wide and Fullwidth characters occupy two
+// columns; all other characters occupy one column.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r >= 0x7f && r < 0xa0:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.IsOneOf(zeroWidthRunes, r):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Width returns the number of columns occupied by s when it is displayed in a terminal. Control sequences recognized
+// by Decode occupy no columns.
+func Width(s string) int {
+	width := 0
+	for len(s) > 0 {
+		if _, size, status := decodeString(s); status == StatusOK {
+			s = s[size:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		width, s = width+RuneWidth(r), s[size:]
+	}
+	return width
+}
+
+// Truncate returns the longest prefix of s that occupies at most width columns. Control sequences in the prefix are
+// kept intact, but control sequences that are not followed by a character that fits are dropped unless they end s. If
+// the prefix leaves a graphics rendition other than the default in effect, Truncate appends a control sequence that
+// resets the graphics rendition.
+func Truncate(s string, width int) string {
+	// style is the graphics rendition in effect at the end of s[:end]; next includes any control sequences after end.
+	var style, next Style
+	end := 0
+	for rest := s; len(rest) > 0; {
+		if cmd, size, status := decodeString(rest); status == StatusOK {
+			next.ApplyCommand(cmd)
+			rest = rest[size:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(rest)
+		w := RuneWidth(r)
+		if w > width {
+			return closeStyle(s[:end], style)
+		}
+		width, rest = width-w, rest[size:]
+		end, style = len(s)-len(rest), next
+	}
+	return closeStyle(s, next)
+}
+
+// PadRight returns s padded with spaces so that it occupies at least width columns. If s leaves a graphics rendition
+// other than the default in effect, PadRight appends a control sequence that resets the graphics rendition before the
+// padding.
+func PadRight(s string, width int) string {
+	var style Style
+	for rest := s; len(rest) > 0; {
+		if cmd, size, status := decodeString(rest); status == StatusOK {
+			style.ApplyCommand(cmd)
+			rest = rest[size:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(rest)
+		width, rest = width-RuneWidth(r), rest[size:]
+	}
+
+	s = closeStyle(s, style)
+	if width > 0 {
+		s += strings.Repeat(" ", width)
+	}
+	return s
+}
+
+// closeStyle appends a control sequence that resets the given graphics rendition to s if necessary.
+func closeStyle(s string, style Style) string {
+	if style == (Style{}) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteString(s)
+	EncodeStyleChange(&b, style, Style{})
+	return b.String()
+}
+
+// decodeString is like DecodeStatus, but decodes the control sequence at the start of a string.
+func decodeString(s string) (Command, int, Status) {
+	if len(s) == 0 || s[0] != 0x1b {
+		return nil, 0, StatusNone
+	}
+
+	// Find the end of the control sequence, if any, so that only its bytes are converted.
+	end := 1
+	if end < len(s) && s[end] == '[' {
+		end++
+		for end < len(s) && s[end] >= 0x20 && s[end] < 0x40 {
+			end++
+		}
+		if end < len(s) {
+			end++
+		}
+	}
+	return DecodeStatus([]byte(s[:end]))
+}
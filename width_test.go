@@ -0,0 +1,85 @@
+package ansicsi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuneWidth(t *testing.T) {
+	cases := []struct {
+		r     rune
+		width int
+	}{
+		{'a', 1}, {' ', 1}, {'\t', 0}, {'\x1b', 0}, {'\u0085', 0}, {'é', 1},
+		{'\u0301', 0}, // combining acute accent
+		{'\u200b', 0}, // zero width space
+		{'\u200d', 0}, // zero width joiner
+		{'\u1161', 0}, // Hangul medial vowel
+		{'世', 2}, {'ア', 2}, {'한', 2}, {'Ａ', 2}, {'ｱ', 1}, {'🙂', 2}, {'→', 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.width, RuneWidth(c.r), "%U", c.r)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"\x1b[1;31mhello\x1b[0m", 5},
+		{"\x1b[38:2::1:2:3m世界\x1b[m!", 5},
+		{"é", 1},
+		{"\x1b[2J\x1b[10;10H", 0},
+		{"\x1b[12", 3},
+		{"\x1bX", 1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.width, Width(c.s), "%q", c.s)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 3, "hel"},
+		{"hello", 0, ""},
+		{"\x1b[1mhello\x1b[0m", 10, "\x1b[1mhello\x1b[0m"},
+		{"\x1b[1mhello\x1b[0m", 5, "\x1b[1mhello\x1b[0m"},
+		{"\x1b[1mhello\x1b[0m world", 3, "\x1b[1mhel\x1b[0m"},
+		{"\x1b[31mred \x1b[1mbold\x1b[0m", 6, "\x1b[31mred \x1b[1mbo\x1b[0m"},
+		{"世界", 3, "世"},
+		{"éé", 1, "é"},
+		{"\x1b[4mab\x1b[24mcd", 3, "\x1b[4mab\x1b[24mc"},
+		{"ab\x1b[31mcd", 2, "ab"},
+		{"\x1b[1mab\x1b[0m\x1b[31mcd", 2, "\x1b[1mab\x1b[0m"},
+		{"ab\x1b[31m", 2, "ab\x1b[31m\x1b[0m"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, Truncate(c.s, c.width), "%q, %d", c.s, c.width)
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	cases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcdef", 5, "abcdef"},
+		{"\x1b[1mabc\x1b[0m", 5, "\x1b[1mabc\x1b[0m  "},
+		{"\x1b[41mabc", 5, "\x1b[41mabc\x1b[0m  "},
+		{"世", 4, "世  "},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, PadRight(c.s, c.width), "%q, %d", c.s, c.width)
+	}
+}
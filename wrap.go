@@ -0,0 +1,136 @@
+package ansicsi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Wrap wraps s into lines that occupy at most width columns. Lines are broken at spaces where possible; words that
+// are wider than width are broken wherever necessary. Spaces at the end of a line are removed. Existing newlines are
+// preserved. Control sequences occupy no columns and are kept intact.
+//
+// Each line of the result renders correctly on its own: a line that leaves a graphics rendition other than the default
+// in effect ends with a control sequence that resets the graphics rendition, and the following line begins with a
+// control sequence that selects it again.
+func Wrap(s string, width int) string {
+	w := wrapper{width: width}
+	for len(s) > 0 {
+		if cmd, size, status := decodeString(s); status == StatusOK {
+			w.style.ApplyCommand(cmd)
+			w.word.WriteString(s[:size])
+			s = s[size:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		switch r {
+		case ' ':
+			w.flushWord()
+			w.gap.WriteByte(' ')
+			w.gapWidth++
+		case '\n':
+			w.flushWord()
+			w.newline()
+		default:
+			w.addRune(s[:size], RuneWidth(r))
+		}
+		s = s[size:]
+	}
+	w.flushWord()
+	w.endLine()
+	return w.out.String()
+}
+
+// wrapper holds the state of a call to Wrap. The text that has not yet been written to out is made up of the current
+// line, a gap of spaces and zero-width words, and the current word.
+type wrapper struct {
+	width int
+	out   strings.Builder
+
+	line      strings.Builder
+	lineWidth int
+	lineStyle Style // The graphics rendition in effect at the end of the current line
+
+	gap         strings.Builder // The pending spaces and any control sequences between them
+	gapWidth    int
+	gapControls strings.Builder // The control sequences in gap
+	gapStyle    Style           // The graphics rendition in effect at the end of gap
+
+	word      strings.Builder
+	wordWidth int
+	style     Style // The graphics rendition in effect at the end of the current word
+}
+
+// addRune adds a rune to the current word, breaking the word if it would otherwise be wider than a line.
+func (w *wrapper) addRune(r string, width int) {
+	if w.wordWidth > 0 && w.wordWidth+width > w.width {
+		w.flushWord()
+		w.newline()
+	}
+	w.word.WriteString(r)
+	w.wordWidth += width
+}
+
+// flushWord moves the gap and the current word to the current line. If they do not fit, the current line is ended
+// and the word starts a new line without the gap. A word that occupies no columns, such as a word made up only of
+// control sequences, joins the gap instead so that it does not commit the pending spaces to the line.
+func (w *wrapper) flushWord() {
+	if w.word.Len() == 0 {
+		return
+	}
+	if w.wordWidth == 0 {
+		w.gap.WriteString(w.word.String())
+		w.gapControls.WriteString(w.word.String())
+		w.gapStyle = w.style
+		w.word.Reset()
+		return
+	}
+	if w.lineWidth > 0 && w.lineWidth+w.gapWidth+w.wordWidth > w.width {
+		w.newline()
+	}
+	w.startLine()
+	w.line.WriteString(w.gap.String())
+	w.line.WriteString(w.word.String())
+	w.lineWidth += w.gapWidth + w.wordWidth
+	w.lineStyle = w.style
+
+	w.resetGap()
+	w.word.Reset()
+	w.wordWidth = 0
+}
+
+// resetGap discards the gap.
+func (w *wrapper) resetGap() {
+	w.gap.Reset()
+	w.gapWidth = 0
+	w.gapControls.Reset()
+}
+
+// startLine selects the graphics rendition in effect at the start of the current line if the line is empty.
+func (w *wrapper) startLine() {
+	if w.line.Len() == 0 {
+		EncodeStyleChange(&w.line, Style{}, w.lineStyle)
+	}
+}
+
+// endLine writes the current line to the output, resetting its graphics rendition if necessary. The spaces in the gap
+// are discarded, but its control sequences are kept.
+func (w *wrapper) endLine() {
+	if w.gapControls.Len() != 0 {
+		w.startLine()
+		w.line.WriteString(w.gapControls.String())
+		w.lineStyle = w.gapStyle
+	}
+	if w.line.Len() != 0 {
+		w.out.WriteString(closeStyle(w.line.String(), w.lineStyle))
+	}
+	w.line.Reset()
+	w.lineWidth = 0
+	w.resetGap()
+}
+
+// newline ends the current line and starts a new one.
+func (w *wrapper) newline() {
+	w.endLine()
+	w.out.WriteByte('\n')
+}
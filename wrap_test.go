@@ -0,0 +1,64 @@
+package ansicsi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	cases := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"", 10, ""},
+		{"hello world", 20, "hello world"},
+		{"hello world", 5, "hello\nworld"},
+		{"hello world", 8, "hello\nworld"},
+		{"hello world  ", 20, "hello world"},
+		{"a b c d e", 3, "a b\nc d\ne"},
+		{"hello   world", 5, "hello\nworld"},
+		{"  indented text", 10, "  indented\ntext"},
+		{"one\ntwo three", 5, "one\ntwo\nthree"},
+		{"one\n\ntwo", 5, "one\n\ntwo"},
+		{"abcdefgh", 3, "abc\ndef\ngh"},
+		{"ab abcdefgh", 3, "ab\nabc\ndef\ngh"},
+		{"世界 世界", 4, "世界\n世界"},
+		{"世界世界", 3, "世\n界\n世\n界"},
+		{"\x1b[1mhello\x1b[0m world", 5, "\x1b[1mhello\x1b[0m\nworld"},
+		{"\x1b[1mhello world\x1b[0m", 5, "\x1b[1mhello\x1b[0m\n\x1b[1mworld\x1b[0m"},
+		{"\x1b[31mred and \x1b[1mbold\x1b[0m text", 7, "\x1b[31mred and\x1b[0m\n\x1b[31m\x1b[1mbold\x1b[0m\ntext"},
+		{"\x1b[4mab\x1b[24mcdef", 3, "\x1b[4mab\x1b[24mc\ndef"},
+		{"\x1b[41mone\n\ntwo", 5, "\x1b[41mone\x1b[0m\n\n\x1b[41mtwo\x1b[0m"},
+		{"\x1b[7mabc", 5, "\x1b[7mabc\x1b[0m"},
+		{"\x1b[2Jclear", 5, "\x1b[2Jclear"},
+		{"ab \x1b[0m", 5, "ab\x1b[0m"},
+		{"ab \x1b[0m\ncd", 5, "ab\x1b[0m\ncd"},
+		{"a \x1b[1m b", 10, "a \x1b[1m b\x1b[0m"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, Wrap(c.s, c.width), "%q, %d", c.s, c.width)
+	}
+}
+
+func TestWrap_LinesStandAlone(t *testing.T) {
+	s := "\x1b[1;38;5;208mThe quick brown \x1b[22;4mfox jumps over\x1b[24m the \x1b[48:2::1:2:3mlazy dog\x1b[0m."
+	for width := 1; width < 20; width++ {
+		for _, line := range strings.Split(Wrap(s, width), "\n") {
+			assert.LessOrEqual(t, Width(line), width, "%q", line)
+
+			var style Style
+			for rest := line; len(rest) > 0; {
+				if cmd, size, status := decodeString(rest); status == StatusOK {
+					style.ApplyCommand(cmd)
+					rest = rest[size:]
+				} else {
+					rest = rest[1:]
+				}
+			}
+			assert.Equal(t, Style{}, style, "%q", line)
+		}
+	}
+}